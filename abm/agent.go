@@ -4,7 +4,11 @@
 
 package abm
 
+import "math/rand/v2"
+
 // Agent is the interface that all agents implement.
+// Types that embed [AgentBase] can override its methods
+// to change the behavior of the agent in each step.
 type Agent interface {
 
 	// Base returns the agent as an [AgentBase].
@@ -12,4 +16,30 @@ type Agent interface {
 
 	// Init initializes the agent with default values in the given simulation.
 	Init(sim Sim)
+
+	// StepPosition updates the agent's position and velocity one time step,
+	// using the given random number generator.
+	StepPosition(rnd *rand.Rand)
+
+	// ApplyValues applies the restorative effect of the agent's values on its beliefs.
+	ApplyValues()
+
+	// Interact has the agent interact with the given other agent, which
+	// updates the beliefs of the other agent through [Agent.Receive]:
+	// the agent influences the other. It is not called when
+	// [SimBase.Rule] is a [GroupInteractionRule].
+	Interact(other Agent)
+
+	// Receive updates the beliefs of the agent as a result of being influenced
+	// by the given source agent, which is called on the other agent by
+	// [Agent.Interact]. Agent types can override it to change how they are
+	// influenced, such as stubborn partisans whose beliefs do not move.
+	// The source passed by [AgentBase.Interact] is its [AgentBase].
+	Receive(source Agent)
+
+	// InteractGroup has the agent interact with all of the given other agents
+	// at once, which updates the beliefs of the agent itself: the others
+	// influence the agent, which is the opposite direction of [Agent.Interact].
+	// It is only called when [SimBase.Rule] is a [GroupInteractionRule].
+	InteractGroup(others []Agent)
 }
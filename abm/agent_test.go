@@ -0,0 +1,46 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package abm
+
+import (
+	"slices"
+	"testing"
+)
+
+// stubbornAgent is an agent whose beliefs are never influenced by others.
+type stubbornAgent struct {
+	AgentBase
+}
+
+func (a *stubbornAgent) Receive(source Agent) {}
+
+func (a *stubbornAgent) InteractGroup(others []Agent) {}
+
+func TestStubbornAgent(t *testing.T) {
+	for _, rule := range []Rules{RuleExtremeBias, RuleHegselmannKrause} {
+		for _, schedule := range SchedulesValues() {
+			s := newTestSim(t, 100, func(cb *ConfigBase) {
+				cb.Rule = rule
+				cb.Schedule = schedule
+				cb.ConfidenceBound = 1
+				cb.InteractionRadius = 20
+			})
+			stubborn := &stubbornAgent{AgentBase: *s.Agents[0].Base()}
+			s.Agents[0] = stubborn
+			s.UpdateIndex()
+			before := slices.Clone(stubborn.Beliefs)
+			other := slices.Clone(s.Agents[1].Base().Beliefs)
+			for range 10 {
+				s.Step()
+			}
+			if !slices.Equal(stubborn.Beliefs, before) {
+				t.Errorf("%v, %v: stubborn agent moved from %v to %v", rule, schedule, before, stubborn.Beliefs)
+			}
+			if slices.Equal(s.Agents[1].Base().Beliefs, other) {
+				t.Errorf("%v, %v: other agents did not move", rule, schedule)
+			}
+		}
+	}
+}
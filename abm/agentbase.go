@@ -31,8 +31,9 @@ type AgentBase struct {
 	// Connections holds the connections between this agent and others.
 	// The key is the ID of the connected agent, and the value is the strength of
 	// the connection (-1 to 1), with negative values indicating an oppositional
-	// connection.
-	// Connections map[uint]float32
+	// connection. Connections are undirected, so they are always present
+	// in both agents; use [AgentBase.Connect] to create them.
+	Connections map[uint64]float32
 
 	// Beliefs contains the agent's beliefs on each belief axis (0 to 1).
 	Beliefs []float32
@@ -45,6 +46,10 @@ type AgentBase struct {
 	// Influence is the agent's influence on others in the simulation
 	// (initial value 0 to 1).
 	Influence float32
+
+	// neighbors caches the result of [AgentBase.Neighbors].
+	// It is nil when it needs to be recomputed.
+	neighbors []uint64
 }
 
 func (ab *AgentBase) Base() *AgentBase {
@@ -58,34 +63,36 @@ func (ab *AgentBase) Init(sim Sim) {
 
 	ab.Sim = sim
 	ab.ID = atomic.AddUint64(&sb.idCounter, 1) - 1
+	ab.Connections = map[uint64]float32{}
+	ab.neighbors = nil
 
-	ab.Beliefs = make([]float32, cb.Beliefs)
-	for i := range ab.Beliefs {
-		ab.Beliefs[i] = rand.Float32()
-	}
+	rnd := sb.Rand
+
+	ab.Beliefs = sb.beliefs.Draw(rnd)
 	ab.Values = slices.Clone(ab.Beliefs)
-	ab.Influence = cb.RandomInfluence*rand.Float32() + (1 - cb.RandomInfluence)
+	ab.Influence = cb.RandomInfluence*rnd.Float32() + (1 - cb.RandomInfluence)
 	if cb.PartisanPosition && cb.Beliefs >= 2 {
 		ab.Position.Set(ab.Beliefs[0], ab.Beliefs[1])
 	} else {
-		ab.Position = math32.Vec2(rand.Float32(), rand.Float32())
+		ab.Position = math32.Vec2(rnd.Float32(), rnd.Float32())
 	}
 }
 
 var zeroVec, oneVec = math32.Vector2{}, math32.Vec2(1, 1)
 
-// StepPosition updates the agent's position and velocity one time step.
-func (ab *AgentBase) StepPosition() {
+// StepPosition updates the agent's position and velocity one time step,
+// using the given random number generator.
+func (ab *AgentBase) StepPosition(rnd *rand.Rand) {
 	cb := ab.Sim.Base().Config.Base()
-	if rand.Float32() < cb.ChangeVelocity {
-		ab.Velocity = math32.Vec2(rand.Float32(), rand.Float32()).SubScalar(0.5).MulScalar(1 - cb.BeliefVelocity)
+	if rnd.Float32() < cb.ChangeVelocity {
+		ab.Velocity = math32.Vec2(rnd.Float32(), rnd.Float32()).SubScalar(0.5).MulScalar(1 - cb.BeliefVelocity)
 		if cb.Beliefs >= 2 {
 			ab.Velocity.SetAdd(math32.Vec2(ab.Beliefs[0], ab.Beliefs[1]).Sub(ab.Position).MulScalar(cb.BeliefVelocity))
 		}
 		ab.Velocity.SetMulScalar(cb.VelocityMultiplier)
 	}
 	ab.Position.SetAdd(ab.Velocity)
-	ab.Position.Clamp(zeroVec, oneVec)
+	cb.Boundary.Apply(&ab.Position, &ab.Velocity)
 }
 
 // ApplyValues applies the restorative effect of the agent's values on its beliefs.
@@ -98,31 +105,36 @@ func (ab *AgentBase) ApplyValues() {
 	}
 }
 
-// Interact has the agent interact with the given other agent.
+// Interact has the agent interact with the given other agent,
+// which updates the beliefs of the other agent through [Agent.Receive].
 func (ab *AgentBase) Interact(other Agent) {
-	cb := ab.Sim.Base().Config.Base()
-	ai := ab.Influence
-	oi := other.Base().Influence
-	for i := range ab.Beliefs {
-		ba := &ab.Beliefs[i]
-		bo := &other.Base().Beliefs[i]
+	other.Receive(ab) // reverse interaction
+}
 
-		// ab.shiftBelief(ba, bo, ai, oi, cb)
-		other.Base().shiftBelief(bo, ba, oi, ai, cb) // reverse interaction
+// Receive updates the beliefs of the agent according to [SimBase.Rule]
+// as a result of being influenced by the given source agent.
+func (ab *AgentBase) Receive(source Agent) {
+	sb := ab.Sim.Base()
+	sb.Rule.Update(ab, source.Base(), sb.Config.Base())
+	ab.ClampBeliefs()
+}
 
-		*ba = math32.Clamp(*ba, 0, 1)
-		*bo = math32.Clamp(*bo, 0, 1)
+// InteractGroup has the agent interact with all of the given other agents
+// at once, which updates the beliefs of the agent according to [SimBase.Rule],
+// which must be a [GroupInteractionRule].
+func (ab *AgentBase) InteractGroup(others []Agent) {
+	sb := ab.Sim.Base()
+	sources := make([]*AgentBase, len(others))
+	for k, o := range others {
+		sources[k] = o.Base()
 	}
+	sb.Rule.(GroupInteractionRule).UpdateGroup(ab, sources, sb.Config.Base())
+	ab.ClampBeliefs()
 }
 
-// shiftBelief shifts the belief ba towards bo based on the influences ai and oi.
-func (ab *AgentBase) shiftBelief(ba, bo *float32, ai, oi float32, cb *ConfigBase) {
-	// The delta is not just based on bo - ba, because talking with someone who
-	// you agree with will move you more strongly in that direction, so it is more
-	// like bo - 0.5. On the other hand, arguments in the middle aren't entirely
-	// un-motivating, just somewhat less persuasive, so the ExtremeBias parameter
-	// determines how much less persuasive they are.
-	baseline := 0.5*cb.ExtremeBias + *ba*(1-cb.ExtremeBias)
-	delta := cb.InteractionEffect * (*bo - baseline)
-	*ba += delta * (oi / ai)
+// ClampBeliefs clamps the beliefs of the agent to the valid range (0 to 1).
+func (ab *AgentBase) ClampBeliefs() {
+	for i, b := range ab.Beliefs {
+		ab.Beliefs[i] = math32.Clamp(b, 0, 1)
+	}
 }
@@ -0,0 +1,67 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package abm
+
+import "cogentcore.org/core/math32"
+
+// Boundaries are the different boundary conditions
+// at the edges of the simulation space.
+type Boundaries int32 //enums:enum -trim-prefix Boundary
+
+const (
+
+	// BoundaryClamp clamps positions to the simulation space,
+	// so agents stop at the edges.
+	BoundaryClamp Boundaries = iota
+
+	// BoundaryReflect reflects agents off of the edges of the
+	// simulation space, flipping their velocity.
+	BoundaryReflect
+
+	// BoundaryTorus wraps positions around the edges of the simulation
+	// space, so that it forms a torus. Distances are also computed
+	// periodically, so agents near opposite edges are close to each other.
+	BoundaryTorus
+)
+
+// Apply applies the boundary condition to the given position and velocity.
+func (b Boundaries) Apply(pos, vel *math32.Vector2) {
+	switch b {
+	case BoundaryReflect:
+		pos.X, vel.X = reflectAxis(pos.X, vel.X)
+		pos.Y, vel.Y = reflectAxis(pos.Y, vel.Y)
+		pos.Clamp(zeroVec, oneVec)
+	case BoundaryTorus:
+		pos.X -= math32.Floor(pos.X)
+		pos.Y -= math32.Floor(pos.Y)
+	default:
+		pos.Clamp(zeroVec, oneVec)
+	}
+}
+
+// reflectAxis reflects the given position and velocity on one axis
+// off of the edges at 0 and 1.
+func reflectAxis(p, v float32) (float32, float32) {
+	if p < 0 {
+		return -p, -v
+	}
+	if p > 1 {
+		return 2 - p, -v
+	}
+	return p, v
+}
+
+// DistanceSquared returns the squared distance between the given
+// positions under the boundary condition.
+func (b Boundaries) DistanceSquared(p, q math32.Vector2) float32 {
+	if b != BoundaryTorus {
+		return p.DistanceToSquared(q)
+	}
+	dx := math32.Abs(p.X - q.X)
+	dy := math32.Abs(p.Y - q.Y)
+	dx = min(dx, 1-dx)
+	dy = min(dy, 1-dy)
+	return dx*dx + dy*dy
+}
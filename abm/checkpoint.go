@@ -0,0 +1,165 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package abm
+
+import (
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+
+	"cogentcore.org/core/base/iox/jsonx"
+	"cogentcore.org/core/math32"
+)
+
+// Checkpoint is the full state of a simulation at a point in time,
+// which can be saved to and opened from a JSON file to pause and resume
+// a simulation or share its exact state. Restoring a checkpoint into a
+// simulation of the same type results in a simulation that continues exactly
+// as the original would have. Only the state of [AgentBase] is saved for
+// each agent, so agent types with additional state must save it separately.
+type Checkpoint struct {
+
+	// Steps is [SimBase.Steps].
+	Steps int
+
+	// IDCounter is the counter used to generate unique agent IDs.
+	IDCounter uint64
+
+	// Rand is the binary state of the random number generator
+	// of the simulation (base64-encoded in JSON).
+	Rand []byte
+
+	// Config is [SimBase.Config].
+	Config Config
+
+	// Agents contains the state of each agent in [SimBase.Agents].
+	Agents []AgentState
+}
+
+// AgentState is the state of an agent in a [Checkpoint].
+// The fields correspond to those of [AgentBase].
+type AgentState struct {
+	ID          uint64
+	Position    math32.Vector2
+	Velocity    math32.Vector2
+	Connections map[uint64]float32
+	Beliefs     []float32
+	Values      []float32
+	Influence   float32
+}
+
+// Checkpoint returns a [Checkpoint] of the current state of the simulation.
+func (sb *SimBase) Checkpoint() (*Checkpoint, error) {
+	rnd, err := sb.source.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	cp := &Checkpoint{
+		Steps:     sb.Steps,
+		IDCounter: sb.idCounter,
+		Rand:      rnd,
+		Config:    sb.Config,
+		Agents:    make([]AgentState, len(sb.Agents)),
+	}
+	for i, a := range sb.Agents {
+		ab := a.Base()
+		cp.Agents[i] = AgentState{
+			ID:          ab.ID,
+			Position:    ab.Position,
+			Velocity:    ab.Velocity,
+			Connections: maps.Clone(ab.Connections),
+			Beliefs:     slices.Clone(ab.Beliefs),
+			Values:      slices.Clone(ab.Values),
+			Influence:   ab.Influence,
+		}
+	}
+	return cp, nil
+}
+
+// Restore restores the state of the simulation from the given [Checkpoint].
+// It validates the checkpoint config, sets [SimBase.Config] to it, and initializes the
+// simulation with it through [Sim.Init], which must result in the same
+// number of agents as in the checkpoint. It then sets the state of each
+// agent and of the simulation from the checkpoint, and calls the
+// [SimBase.OnInit] callbacks. If the checkpoint cannot be restored, it
+// returns an error without changing the simulation; to check the number
+// of agents first, it initializes a separate simulation of the same type.
+func (sb *SimBase) Restore(cp *Checkpoint) error {
+	if err := cp.Config.Validate(); err != nil {
+		return err
+	}
+	probe := reflect.New(reflect.TypeOf(sb.This).Elem()).Interface().(Sim)
+	probe.Base().This = probe
+	probe.Base().Config = cp.Config
+	if err := probe.Init(); err != nil {
+		return err
+	}
+	if n := len(probe.Base().Agents); n != len(cp.Agents) {
+		return fmt.Errorf("abm.SimBase.Restore: checkpoint has %d agents, but simulation has %d agents after initialization", len(cp.Agents), n)
+	}
+	// the random state is checked with a separate source
+	// so that an invalid state does not change the simulation
+	if err := rand.NewPCG(0, 0).UnmarshalBinary(cp.Rand); err != nil {
+		return err
+	}
+
+	sb.Config = cp.Config
+	sb.restoring = true
+	err := sb.This.Init()
+	sb.restoring = false
+	if err != nil {
+		return err
+	}
+	for i, a := range sb.Agents {
+		ab := a.Base()
+		as := &cp.Agents[i]
+		ab.ID = as.ID
+		ab.Position = as.Position
+		ab.Velocity = as.Velocity
+		ab.Connections = as.Connections
+		if ab.Connections == nil {
+			ab.Connections = map[uint64]float32{}
+		}
+		ab.neighbors = nil
+		ab.Beliefs = as.Beliefs
+		ab.Values = as.Values
+		ab.Influence = as.Influence
+	}
+	if err := sb.source.UnmarshalBinary(cp.Rand); err != nil {
+		return err
+	}
+	sb.Steps = cp.Steps
+	sb.idCounter = cp.IDCounter
+	sb.UpdateIndex()
+	sb.sendSim(&sb.hooks.init)
+	return nil
+}
+
+// SaveCheckpoint saves a [Checkpoint] of the current state of the
+// simulation to the given JSON file.
+func (sb *SimBase) SaveCheckpoint(filename string) error {
+	cp, err := sb.Checkpoint()
+	if err != nil {
+		return err
+	}
+	return jsonx.Save(cp, filename)
+}
+
+// OpenCheckpoint restores the state of the simulation from a [Checkpoint]
+// in the given JSON file, as saved by [SimBase.SaveCheckpoint].
+// The simulation must be of the same type as the one that was saved.
+// The configuration is read into a new value of the same type as
+// [SimBase.Config], so the current configuration is not changed
+// if the checkpoint cannot be restored.
+func (sb *SimBase) OpenCheckpoint(filename string) error {
+	cfg := reflect.New(reflect.TypeOf(sb.Config).Elem()).Interface().(Config)
+	cp := &Checkpoint{Config: cfg}
+	if err := jsonx.Open(cp, filename); err != nil {
+		return err
+	}
+	return sb.Restore(cp)
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package abm
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"cogentcore.org/core/base/iox/jsonx"
+)
+
+func TestCheckpointResume(t *testing.T) {
+	for _, parallel := range []bool{false, true} {
+		for _, schedule := range SchedulesValues() {
+			name := schedule.String()
+			if parallel {
+				name += "/Parallel"
+			}
+			t.Run(name, func(t *testing.T) {
+				configure := func(cb *ConfigBase) {
+					cb.Parallel = parallel
+					cb.Schedule = schedule
+					cb.Interaction = InteractionMixed
+					cb.Network = NetworkBarabasiAlbert
+					cb.InteractionRadius = 5
+				}
+				want := newTestSim(t, 300, configure)
+				for range 20 {
+					want.Step()
+				}
+
+				s := newTestSim(t, 300, configure)
+				for range 10 {
+					s.Step()
+				}
+				filename := filepath.Join(t.TempDir(), "checkpoint.json")
+				if err := s.SaveCheckpoint(filename); err != nil {
+					t.Fatal(err)
+				}
+
+				got := newTestSim(t, 300, func(cb *ConfigBase) { cb.Seed = 2 })
+				if err := got.OpenCheckpoint(filename); err != nil {
+					t.Fatal(err)
+				}
+				for range 10 {
+					got.Step()
+				}
+				if got.Steps != want.Steps {
+					t.Fatalf("got %d steps, want %d", got.Steps, want.Steps)
+				}
+				checkSameAgents(t, got, want)
+				for i, a := range got.Agents {
+					if !slices.Equal(a.Base().Neighbors(), want.Agents[i].Base().Neighbors()) {
+						t.Fatalf("agent %d has different ties after resuming", i)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestRestoreInvalid(t *testing.T) {
+	s := newTestSim(t, 50, nil)
+	want := newTestSim(t, 50, nil)
+	for range 3 {
+		s.Step()
+		want.Step()
+	}
+	inits := 0
+	s.OnInit(func(sim Sim) { inits++ })
+
+	// a checkpoint with an invalid configuration
+	bad, err := newTestSim(t, 20, nil).Checkpoint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	bad.Config.(*testConfig).Population = -5
+	filename := filepath.Join(t.TempDir(), "checkpoint.json")
+	if err := jsonx.Save(bad, filename); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.OpenCheckpoint(filename); err == nil {
+		t.Fatal("got no error for a checkpoint with an invalid configuration")
+	}
+
+	// a checkpoint with a different number of agents than its configuration
+	bad.Config.(*testConfig).Population = 20
+	bad.Agents = bad.Agents[:10]
+	if err := s.Restore(bad); err == nil {
+		t.Fatal("got no error for a checkpoint with the wrong number of agents")
+	}
+
+	if got := s.Config.(*testConfig).Population; got != 50 {
+		t.Fatalf("got population %d after failed restores, want 50", got)
+	}
+	if s.Steps != want.Steps || inits != 0 {
+		t.Fatalf("got %d steps and %d inits after failed restores, want %d and 0", s.Steps, inits, want.Steps)
+	}
+	checkSameAgents(t, s, want)
+}
+
+func TestRestoreOnInit(t *testing.T) {
+	s := newTestSim(t, 50, nil)
+	for range 5 {
+		s.Step()
+	}
+	cp, err := s.Checkpoint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := newTestSim(t, 50, nil)
+	var steps []int
+	got.OnInit(func(sim Sim) { steps = append(steps, sim.Base().Steps) })
+	if err := got.Restore(cp); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(steps, []int{5}) {
+		t.Fatalf("OnInit was called at steps %v, want [5]", steps)
+	}
+	checkSameAgents(t, got, s)
+}
@@ -19,6 +19,12 @@ type Config interface {
 	// Defaults specified via `default:"..."` struct tags are set automatically
 	// (in [NewConfig]).
 	Defaults()
+
+	// Validate returns an error describing every field with an invalid value,
+	// or nil if the configuration is valid. Configurations that embed
+	// [ConfigBase] should include the result of [ConfigBase.Validate]
+	// (see [errors.Join]).
+	Validate() error
 }
 
 // NewConfig creates and initializes a new configuration of type C.
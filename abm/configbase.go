@@ -4,12 +4,67 @@
 
 package abm
 
+import "cogentcore.org/core/base/errors"
+
 // ConfigBase is the base type for configuration parameter sets.
 type ConfigBase struct { //types:add
 
+	// Seed is the seed for the random number generator of the simulation.
+	// The same configuration and seed always result in the same simulation.
+	Seed uint64 `default:"1"`
+
+	// Parallel determines whether simulation steps are computed in parallel
+	// across all available CPU cores. Parallel steps first move all agents
+	// and then compute all interactions, so they give different results than
+	// sequential steps, but the results are still fully determined by the
+	// configuration and seed, regardless of the number of cores.
+	Parallel bool `default:"false"`
+
+	// Schedule is the schedule for updating agent beliefs in each step.
+	Schedule Schedules `default:"Asynchronous"`
+
 	// Beliefs is the number of political belief axes in the simulation.
 	Beliefs int `default:"2"`
 
+	// Distribution is the probability distribution that the
+	// initial beliefs of agents are drawn from.
+	Distribution Distributions `default:"Uniform"`
+
+	// BeliefMean is the mean of the initial beliefs on each axis for
+	// the Normal and Multivariate distributions, and the center
+	// between the two modes of the Bimodal distribution.
+	BeliefMean float32 `default:"0.5"`
+
+	// BeliefSD is the standard deviation of the initial beliefs on each axis for
+	// the Normal and Multivariate distributions, and of each mode of the
+	// Bimodal distribution (before truncation to the range from 0 to 1).
+	BeliefSD float32 `default:"0.2"`
+
+	// BetaA is the first shape parameter (alpha) of the Beta distribution.
+	BetaA float32 `default:"2"`
+
+	// BetaB is the second shape parameter (beta) of the Beta distribution.
+	BetaB float32 `default:"2"`
+
+	// BimodalSeparation is the distance between the centers of the
+	// two modes of the Bimodal distribution.
+	BimodalSeparation float32 `default:"0.5"`
+
+	// BimodalWeight is the proportion of agents in the upper
+	// mode of the Bimodal distribution.
+	BimodalWeight float32 `default:"0.5"`
+
+	// BeliefCorrelation is the correlation between the initial beliefs on
+	// every pair of axes for the Multivariate distribution, which models
+	// ideological constraint. It is only used if BeliefCovariance is not set.
+	BeliefCorrelation float32 `default:"0"`
+
+	// BeliefCovariance is the full covariance matrix of the initial beliefs
+	// for the Multivariate distribution, with one row and column per belief
+	// axis. If it is set, BeliefSD and BeliefCorrelation are not used
+	// for the Multivariate distribution.
+	BeliefCovariance [][]float32
+
 	// PartisanPosition determines whether agents are initialized with a
 	// spatial position corresponding to their beliefs, as in the seating of
 	// an elected legislature (only applicable for Beliefs >= 2).
@@ -31,6 +86,13 @@ type ConfigBase struct { //types:add
 	// agents move.
 	VelocityMultiplier float32 `default:"0.01"`
 
+	// Interaction determines how agents choose their interaction partners:
+	// by spatial proximity, through the social network, or both.
+	Interaction Interactions `default:"Spatial"`
+
+	// Boundary is the boundary condition at the edges of the simulation space.
+	Boundary Boundaries `default:"Clamp"`
+
 	// InteractionRadius is the multiplier on the maximum squared distance between
 	// agents for an interaction to occur, with the base value being 1/n
 	// (n = total number of agents).
@@ -42,6 +104,9 @@ type ConfigBase struct { //types:add
 	// make that chance 15%. A value of 0 disables belief filtering.
 	BeliefFilter float32 `default:"0.5"`
 
+	// Rule is the rule that determines how beliefs change in interactions.
+	Rule Rules `default:"ExtremeBias"`
+
 	// ExtremeBias is the bias that agents have toward extreme beliefs.
 	// (i.e., beliefs closer to 0 or 1 have a greater influence in interactions
 	// than those closer to 0.5).
@@ -51,11 +116,42 @@ type ConfigBase struct { //types:add
 	// proportion of the initial difference in beliefs.
 	InteractionEffect float32 `default:"0.01"`
 
+	// ConfidenceBound is the maximum normalized belief distance at which
+	// agents influence each other in the bounded confidence rules
+	// (Deffuant–Weisbuch and Hegselmann–Krause), and at which agents
+	// assimilate beliefs in the Jager–Amblard rule.
+	ConfidenceBound float32 `default:"0.2"`
+
+	// RejectionBound is the minimum normalized belief distance at which
+	// agents reject each other's beliefs in the Jager–Amblard rule.
+	RejectionBound float32 `default:"0.6"`
+
 	// ValueEffect is how much an agent's immutable values impact their beliefs
 	// as a proportion of the difference between beliefs and values.
 	// Values have a kind of restorative force, pulling beliefs back to the original
 	// values over time.
 	ValueEffect float32 `default:"0.01"`
+
+	// Network is the type of social network generated between agents.
+	Network Networks `default:"None"`
+
+	// NetworkDegree is the target mean number of ties per agent in the
+	// social network. For Watts–Strogatz networks, it is rounded down to an
+	// even number, and for Barabási–Albert networks, each new agent forms
+	// half of this number of ties.
+	NetworkDegree int `default:"6"`
+
+	// NetworkRewire is the probability of rewiring each tie in a
+	// Watts–Strogatz network.
+	NetworkRewire float32 `default:"0.1"`
+
+	// NetworkPartners is the number of social network neighbors that each
+	// agent interacts with in each step (for Network and Mixed interaction).
+	NetworkPartners int `default:"1"`
+
+	// NegativeTies is the proportion of ties in the social network that are
+	// oppositional (negative strength). The magnitude of tie strengths is random.
+	NegativeTies float32 `default:"0"`
 }
 
 func (cb *ConfigBase) Base() *ConfigBase {
@@ -63,3 +159,48 @@ func (cb *ConfigBase) Base() *ConfigBase {
 }
 
 func (cb *ConfigBase) Defaults() {}
+
+func (cb *ConfigBase) Validate() error {
+	return errors.Join(
+		CheckEnum("Schedule", cb.Schedule),
+		CheckMin("Beliefs", cb.Beliefs, 1),
+		CheckEnum("Distribution", cb.Distribution),
+		CheckRange("BeliefMean", cb.BeliefMean, 0, 1),
+		CheckMin("BeliefSD", cb.BeliefSD, 0),
+		CheckPositive("BetaA", cb.BetaA),
+		CheckPositive("BetaB", cb.BetaB),
+		CheckRange("BimodalSeparation", cb.BimodalSeparation, 0, 1),
+		CheckRange("BimodalWeight", cb.BimodalWeight, 0, 1),
+		CheckRange("BeliefCorrelation", cb.BeliefCorrelation, -1, 1),
+		cb.checkCovariance(),
+		CheckRange("RandomInfluence", cb.RandomInfluence, 0, 1),
+		CheckRange("ChangeVelocity", cb.ChangeVelocity, 0, 1),
+		CheckRange("BeliefVelocity", cb.BeliefVelocity, 0, 1),
+		CheckMin("VelocityMultiplier", cb.VelocityMultiplier, 0),
+		CheckEnum("Interaction", cb.Interaction),
+		CheckEnum("Boundary", cb.Boundary),
+		CheckMin("InteractionRadius", cb.InteractionRadius, 0),
+		CheckMin("BeliefFilter", cb.BeliefFilter, 0),
+		CheckEnum("Rule", cb.Rule),
+		CheckRange("ExtremeBias", cb.ExtremeBias, 0, 1),
+		CheckRange("InteractionEffect", cb.InteractionEffect, 0, 1),
+		CheckRange("ConfidenceBound", cb.ConfidenceBound, 0, 1),
+		cb.checkRejectionBound(),
+		CheckRange("ValueEffect", cb.ValueEffect, 0, 1),
+		CheckEnum("Network", cb.Network),
+		CheckMin("NetworkDegree", cb.NetworkDegree, 0),
+		CheckRange("NetworkRewire", cb.NetworkRewire, 0, 1),
+		CheckMin("NetworkPartners", cb.NetworkPartners, 0),
+		CheckRange("NegativeTies", cb.NegativeTies, 0, 1),
+	)
+}
+
+// checkRejectionBound returns an error if [ConfigBase.RejectionBound] is
+// invalid for [RuleJagerAmblard], which is the only rule that uses it,
+// and nil otherwise.
+func (cb *ConfigBase) checkRejectionBound() error {
+	if cb.Rule != RuleJagerAmblard {
+		return nil
+	}
+	return CheckRange("RejectionBound", cb.RejectionBound, cb.ConfidenceBound, 1)
+}
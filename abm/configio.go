@@ -0,0 +1,40 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package abm
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"cogentcore.org/core/base/iox/jsonx"
+	"cogentcore.org/core/base/iox/tomlx"
+)
+
+// OpenConfig sets the given configuration from the given file, which
+// must be a TOML (.toml) or JSON (.json) file. Fields that are not
+// specified in the file keep their current values, and fields of
+// embedded structs such as [ConfigBase] are specified directly by name.
+func OpenConfig(cfg Config, filename string) error {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".toml":
+		return tomlx.Open(cfg, filename)
+	case ".json":
+		return jsonx.Open(cfg, filename)
+	}
+	return fmt.Errorf("abm.OpenConfig: unsupported file extension for %q (must be .toml or .json)", filename)
+}
+
+// SaveConfig saves the given configuration to the given file, which
+// must be a TOML (.toml) or JSON (.json) file.
+func SaveConfig(cfg Config, filename string) error {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".toml":
+		return tomlx.Save(cfg, filename)
+	case ".json":
+		return jsonx.SaveIndent(cfg, filename)
+	}
+	return fmt.Errorf("abm.SaveConfig: unsupported file extension for %q (must be .toml or .json)", filename)
+}
@@ -0,0 +1,267 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package abm
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+)
+
+// Distributions are the different probability distributions
+// that the initial beliefs of agents can be drawn from.
+type Distributions int32 //enums:enum -trim-prefix Distribution
+
+const (
+
+	// DistributionUniform draws each belief independently
+	// from a uniform distribution from 0 to 1.
+	DistributionUniform Distributions = iota
+
+	// DistributionNormal draws each belief independently from a normal
+	// distribution with mean [ConfigBase.BeliefMean] and standard deviation
+	// [ConfigBase.BeliefSD], truncated to the range from 0 to 1.
+	DistributionNormal
+
+	// DistributionBeta draws each belief independently from a beta
+	// distribution with shape parameters [ConfigBase.BetaA] and [ConfigBase.BetaB].
+	DistributionBeta
+
+	// DistributionBimodal draws beliefs from a mixture of two truncated normal
+	// distributions with standard deviation [ConfigBase.BeliefSD], centered
+	// [ConfigBase.BimodalSeparation] apart around [ConfigBase.BeliefMean]. Each
+	// agent belongs to the upper mode with probability [ConfigBase.BimodalWeight],
+	// and it belongs to the same mode on all belief axes, like members of two
+	// opposing camps.
+	DistributionBimodal
+
+	// DistributionMultivariate draws beliefs from a multivariate normal
+	// distribution with mean [ConfigBase.BeliefMean] on each axis, truncated
+	// to the range from 0 to 1. The covariance matrix is
+	// [ConfigBase.BeliefCovariance] if it is set, and otherwise it has variance
+	// [ConfigBase.BeliefSD]^2 and correlation [ConfigBase.BeliefCorrelation]
+	// between every pair of axes. Correlations between axes model
+	// ideological constraint.
+	DistributionMultivariate
+)
+
+// maxTruncatedDraws is the maximum number of draws from a normal distribution
+// to get a value in range before clamping it instead.
+const maxTruncatedDraws = 100
+
+// BeliefDistribution is the distribution of initial beliefs given by
+// [ConfigBase.Distribution], with any preparation needed for drawing
+// from it done only once.
+type BeliefDistribution struct {
+
+	// Config is the configuration with the parameters of the distribution.
+	Config *ConfigBase
+
+	// factor is the lower triangular Cholesky factor of the
+	// covariance matrix for [DistributionMultivariate].
+	factor [][]float64
+}
+
+// BeliefDistribution returns the [BeliefDistribution] for the configuration.
+// It returns an error if the covariance matrix of [DistributionMultivariate]
+// is not valid, which [ConfigBase.Validate] also checks. The distribution
+// must be recreated whenever the configuration changes.
+func (cb *ConfigBase) BeliefDistribution() (*BeliefDistribution, error) {
+	bd := &BeliefDistribution{Config: cb}
+	if cb.Distribution == DistributionMultivariate {
+		var err error
+		bd.factor, err = cholesky(cb.Covariance())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return bd, nil
+}
+
+// InitialBeliefs returns initial beliefs for an agent drawn from
+// [ConfigBase.Distribution] using the given random number generator.
+// It returns an error if the covariance matrix of [DistributionMultivariate]
+// is not valid. Use [ConfigBase.BeliefDistribution] to draw beliefs
+// for many agents.
+func (cb *ConfigBase) InitialBeliefs(rnd *rand.Rand) ([]float32, error) {
+	bd, err := cb.BeliefDistribution()
+	if err != nil {
+		return nil, err
+	}
+	return bd.Draw(rnd), nil
+}
+
+// Draw returns initial beliefs for an agent drawn from
+// the distribution using the given random number generator.
+func (bd *BeliefDistribution) Draw(rnd *rand.Rand) []float32 {
+	cb := bd.Config
+	beliefs := make([]float32, cb.Beliefs)
+	switch cb.Distribution {
+	case DistributionUniform:
+		for i := range beliefs {
+			beliefs[i] = rnd.Float32()
+		}
+	case DistributionNormal:
+		for i := range beliefs {
+			beliefs[i] = truncatedNormal(rnd, float64(cb.BeliefMean), float64(cb.BeliefSD))
+		}
+	case DistributionBeta:
+		for i := range beliefs {
+			x := gamma(rnd, float64(cb.BetaA))
+			beliefs[i] = float32(x / (x + gamma(rnd, float64(cb.BetaB))))
+		}
+	case DistributionBimodal:
+		mean := float64(cb.BeliefMean - cb.BimodalSeparation/2)
+		if rnd.Float32() < cb.BimodalWeight {
+			mean += float64(cb.BimodalSeparation)
+		}
+		for i := range beliefs {
+			beliefs[i] = truncatedNormal(rnd, mean, float64(cb.BeliefSD))
+		}
+	case DistributionMultivariate:
+		multivariateNormal(rnd, float64(cb.BeliefMean), bd.factor, beliefs)
+	}
+	return beliefs
+}
+
+// Covariance returns the covariance matrix of beliefs
+// for [DistributionMultivariate].
+func (cb *ConfigBase) Covariance() [][]float64 {
+	n := cb.Beliefs
+	cov := make([][]float64, n)
+	variance := float64(cb.BeliefSD * cb.BeliefSD)
+	for i := range cov {
+		cov[i] = make([]float64, n)
+		for j := range cov[i] {
+			switch {
+			case len(cb.BeliefCovariance) > 0:
+				cov[i][j] = float64(cb.BeliefCovariance[i][j])
+			case i == j:
+				cov[i][j] = variance
+			default:
+				cov[i][j] = float64(cb.BeliefCorrelation) * variance
+			}
+		}
+	}
+	return cov
+}
+
+// checkCovariance returns an error if the covariance matrix for
+// [DistributionMultivariate] is invalid, and nil otherwise.
+func (cb *ConfigBase) checkCovariance() error {
+	if cb.Distribution != DistributionMultivariate {
+		return nil
+	}
+	if n := len(cb.BeliefCovariance); n > 0 {
+		if n != cb.Beliefs {
+			return fmt.Errorf("BeliefCovariance must have %d rows (one per belief axis), but it has %d", cb.Beliefs, n)
+		}
+		for i, row := range cb.BeliefCovariance {
+			if len(row) != cb.Beliefs {
+				return fmt.Errorf("BeliefCovariance row %d must have %d values (one per belief axis), but it has %d", i, cb.Beliefs, len(row))
+			}
+			for j := range i {
+				if row[j] != cb.BeliefCovariance[j][i] {
+					return fmt.Errorf("BeliefCovariance must be symmetric, but it has %v at (%d, %d) and %v at (%d, %d)", row[j], i, j, cb.BeliefCovariance[j][i], j, i)
+				}
+			}
+		}
+	}
+	if _, err := cholesky(cb.Covariance()); err != nil {
+		if len(cb.BeliefCovariance) > 0 {
+			return errors.New("BeliefCovariance must be positive definite")
+		}
+		return fmt.Errorf("BeliefSD must be positive and BeliefCorrelation must be greater than %v for %d belief axes", -1/float64(max(cb.Beliefs-1, 1)), cb.Beliefs)
+	}
+	return nil
+}
+
+// truncatedNormal returns a value from 0 to 1 drawn from a normal
+// distribution with the given mean and standard deviation,
+// truncated to that range.
+func truncatedNormal(rnd *rand.Rand, mean, sd float64) float32 {
+	x := mean
+	for range maxTruncatedDraws {
+		x = mean + sd*rnd.NormFloat64()
+		if x >= 0 && x <= 1 {
+			break
+		}
+	}
+	return float32(min(max(x, 0), 1))
+}
+
+// multivariateNormal sets dst to a vector drawn from a multivariate normal
+// distribution with the given mean on each axis and the given lower
+// triangular Cholesky factor of the covariance matrix, truncated to
+// the range from 0 to 1 on each axis.
+func multivariateNormal(rnd *rand.Rand, mean float64, l [][]float64, dst []float32) {
+	z := make([]float64, len(dst))
+	for range maxTruncatedDraws {
+		for i := range z {
+			z[i] = rnd.NormFloat64()
+		}
+		in := true
+		for i := range dst {
+			x := mean
+			for j := range i + 1 {
+				x += l[i][j] * z[j]
+			}
+			in = in && x >= 0 && x <= 1
+			dst[i] = float32(min(max(x, 0), 1))
+		}
+		if in {
+			return
+		}
+	}
+}
+
+// gamma returns a value drawn from a gamma distribution with
+// the given shape and a scale of 1, using the method of
+// Marsaglia and Tsang (2000).
+func gamma(rnd *rand.Rand, shape float64) float64 {
+	if shape < 1 {
+		return gamma(rnd, shape+1) * math.Pow(rnd.Float64(), 1/shape)
+	}
+	d := shape - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := rnd.NormFloat64()
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := rnd.Float64()
+		if math.Log(u) < x*x/2+d-d*v+d*math.Log(v) {
+			return d * v
+		}
+	}
+}
+
+// cholesky returns the lower triangular Cholesky factor of the given
+// symmetric matrix, or an error if it is not positive definite.
+func cholesky(m [][]float64) ([][]float64, error) {
+	n := len(m)
+	l := make([][]float64, n)
+	for i := range l {
+		l[i] = make([]float64, n)
+		for j := range i + 1 {
+			sum := m[i][j]
+			for k := range j {
+				sum -= l[i][k] * l[j][k]
+			}
+			if i == j {
+				if sum <= 0 {
+					return nil, errors.New("abm.cholesky: matrix is not positive definite")
+				}
+				l[i][i] = math.Sqrt(sum)
+			} else {
+				l[i][j] = sum / l[j][j]
+			}
+		}
+	}
+	return l, nil
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package abm
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+// sampleBeliefs returns n draws of initial beliefs from the given
+// configuration, failing the test if any belief is not from 0 to 1.
+func sampleBeliefs(t *testing.T, cb *ConfigBase, n int) [][]float32 {
+	t.Helper()
+	if err := cb.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	bd, err := cb.BeliefDistribution()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rnd := rand.New(rand.NewPCG(1, 2))
+	draws := make([][]float32, n)
+	for k := range draws {
+		draws[k] = bd.Draw(rnd)
+		for i, b := range draws[k] {
+			if b < 0 || b > 1 {
+				t.Fatalf("%v: belief %d of draw %d is %g", cb.Distribution, i, k, b)
+			}
+		}
+	}
+	return draws
+}
+
+// moments returns the sample mean and variance of the beliefs
+// on the given axis, and their correlation with the next axis
+// if there is one.
+func moments(draws [][]float32, axis int) (mean, variance, corr float64) {
+	n := float64(len(draws))
+	var next, sx, sy, sxx, syy, sxy float64
+	for _, d := range draws {
+		x := float64(d[axis])
+		if axis+1 < len(d) {
+			next = float64(d[axis+1])
+		}
+		sx += x
+		sy += next
+		sxx += x * x
+		syy += next * next
+		sxy += x * next
+	}
+	mean = sx / n
+	variance = sxx/n - mean*mean
+	vy := syy/n - (sy/n)*(sy/n)
+	corr = (sxy/n - mean*sy/n) / math.Sqrt(variance*vy)
+	return
+}
+
+// checkClose fails the test if got is not within tolerance of want.
+func checkClose(t *testing.T, name string, got, want, tolerance float64) {
+	t.Helper()
+	if math.Abs(got-want) > tolerance {
+		t.Errorf("got %s %g, want %g", name, got, want)
+	}
+}
+
+func newDistributionConfig(d Distributions, beliefs int) *ConfigBase {
+	cb := NewConfig[ConfigBase]()
+	cb.Distribution = d
+	cb.Beliefs = beliefs
+	return cb
+}
+
+func TestNormalBeliefs(t *testing.T) {
+	cb := newDistributionConfig(DistributionNormal, 2)
+	cb.BeliefMean, cb.BeliefSD = 0.4, 0.1
+	mean, variance, corr := moments(sampleBeliefs(t, cb, 20000), 0)
+	checkClose(t, "mean", mean, 0.4, 0.005)
+	checkClose(t, "SD", math.Sqrt(variance), 0.1, 0.005)
+	checkClose(t, "correlation", corr, 0, 0.03)
+
+	// truncation keeps every belief in range even with a large SD
+	cb.BeliefMean, cb.BeliefSD = 0.9, 2
+	sampleBeliefs(t, cb, 2000)
+}
+
+func TestBetaBeliefs(t *testing.T) {
+	for _, ab := range [][2]float32{{2, 5}, {0.5, 0.5}} {
+		cb := newDistributionConfig(DistributionBeta, 1)
+		cb.BetaA, cb.BetaB = ab[0], ab[1]
+		a, b := float64(ab[0]), float64(ab[1])
+		mean, variance, _ := moments(sampleBeliefs(t, cb, 20000), 0)
+		checkClose(t, "mean", mean, a/(a+b), 0.01)
+		checkClose(t, "variance", variance, a*b/((a+b)*(a+b)*(a+b+1)), 0.005)
+	}
+}
+
+func TestBimodalBeliefs(t *testing.T) {
+	cb := newDistributionConfig(DistributionBimodal, 2)
+	cb.BeliefMean, cb.BeliefSD = 0.5, 0.05
+	cb.BimodalSeparation, cb.BimodalWeight = 0.5, 0.3
+	draws := sampleBeliefs(t, cb, 20000)
+	mean, _, corr := moments(draws, 0)
+	checkClose(t, "mean", mean, 0.7*0.25+0.3*0.75, 0.01)
+	upper := 0
+	for k, d := range draws {
+		if (d[0] > 0.5) != (d[1] > 0.5) {
+			t.Fatalf("draw %d is in different modes on different axes: %v", k, d)
+		}
+		if d[0] > 0.5 {
+			upper++
+		}
+	}
+	checkClose(t, "upper mode proportion", float64(upper)/float64(len(draws)), 0.3, 0.01)
+	if corr < 0.9 {
+		t.Errorf("got correlation %g between axes, want close to 1", corr)
+	}
+}
+
+func TestMultivariateBeliefs(t *testing.T) {
+	cb := newDistributionConfig(DistributionMultivariate, 3)
+	cb.BeliefSD, cb.BeliefCorrelation = 0.1, 0.6
+	draws := sampleBeliefs(t, cb, 20000)
+	for axis := range 2 {
+		mean, variance, corr := moments(draws, axis)
+		checkClose(t, "mean", mean, 0.5, 0.005)
+		checkClose(t, "SD", math.Sqrt(variance), 0.1, 0.005)
+		checkClose(t, "correlation", corr, 0.6, 0.02)
+	}
+
+	cb = newDistributionConfig(DistributionMultivariate, 2)
+	cb.BeliefCovariance = [][]float32{{0.01, -0.004}, {-0.004, 0.0064}}
+	mean, variance, corr := moments(sampleBeliefs(t, cb, 20000), 0)
+	checkClose(t, "mean", mean, 0.5, 0.005)
+	checkClose(t, "variance", variance, 0.01, 0.0005)
+	checkClose(t, "correlation", corr, -0.5, 0.02)
+}
+
+func TestInvalidCovariance(t *testing.T) {
+	cb := newDistributionConfig(DistributionMultivariate, 2)
+	cb.BeliefCovariance = [][]float32{{0.01, 0.02}, {0.02, 0.01}}
+	if err := cb.Validate(); err == nil {
+		t.Error("got no error from Validate for a covariance matrix that is not positive definite")
+	}
+	if _, err := cb.BeliefDistribution(); err == nil {
+		t.Error("got no error from BeliefDistribution for a covariance matrix that is not positive definite")
+	}
+
+	// the correlation between every pair of 3 axes must be greater than -1/2
+	cb = newDistributionConfig(DistributionMultivariate, 3)
+	cb.BeliefCorrelation = -0.6
+	if err := cb.Validate(); err == nil {
+		t.Error("got no error from Validate for a correlation of -0.6 between 3 axes")
+	}
+}
+
+func TestCholesky(t *testing.T) {
+	m := [][]float64{{4, 2, -2}, {2, 10, 4}, {-2, 4, 9}}
+	l, err := cholesky(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range m {
+		for j := range m {
+			sum := 0.0
+			for k := range m {
+				sum += l[i][k] * l[j][k]
+			}
+			if math.Abs(sum-m[i][j]) > 1e-12 {
+				t.Fatalf("L Lᵀ has %g at (%d, %d), want %g", sum, i, j, m[i][j])
+			}
+			if j > i && l[i][j] != 0 {
+				t.Fatalf("factor is not lower triangular: %v", l)
+			}
+		}
+	}
+}
@@ -0,0 +1,259 @@
+// Code generated by "core generate"; DO NOT EDIT.
+
+package abm
+
+import (
+	"cogentcore.org/core/enums"
+)
+
+var _BoundariesValues = []Boundaries{0, 1, 2}
+
+// BoundariesN is the highest valid value for type Boundaries, plus one.
+const BoundariesN Boundaries = 3
+
+var _BoundariesValueMap = map[string]Boundaries{`Clamp`: 0, `Reflect`: 1, `Torus`: 2}
+
+var _BoundariesDescMap = map[Boundaries]string{0: `BoundaryClamp clamps positions to the simulation space, so agents stop at the edges.`, 1: `BoundaryReflect reflects agents off of the edges of the simulation space, flipping their velocity.`, 2: `BoundaryTorus wraps positions around the edges of the simulation space, so that it forms a torus. Distances are also computed periodically, so agents near opposite edges are close to each other.`}
+
+var _BoundariesMap = map[Boundaries]string{0: `Clamp`, 1: `Reflect`, 2: `Torus`}
+
+// String returns the string representation of this Boundaries value.
+func (i Boundaries) String() string { return enums.String(i, _BoundariesMap) }
+
+// SetString sets the Boundaries value from its string representation,
+// and returns an error if the string is invalid.
+func (i *Boundaries) SetString(s string) error {
+	return enums.SetString(i, s, _BoundariesValueMap, "Boundaries")
+}
+
+// Int64 returns the Boundaries value as an int64.
+func (i Boundaries) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the Boundaries value from an int64.
+func (i *Boundaries) SetInt64(in int64) { *i = Boundaries(in) }
+
+// Desc returns the description of the Boundaries value.
+func (i Boundaries) Desc() string { return enums.Desc(i, _BoundariesDescMap) }
+
+// BoundariesValues returns all possible values for the type Boundaries.
+func BoundariesValues() []Boundaries { return _BoundariesValues }
+
+// Values returns all possible values for the type Boundaries.
+func (i Boundaries) Values() []enums.Enum { return enums.Values(_BoundariesValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i Boundaries) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *Boundaries) UnmarshalText(text []byte) error {
+	return enums.UnmarshalText(i, text, "Boundaries")
+}
+
+var _DistributionsValues = []Distributions{0, 1, 2, 3, 4}
+
+// DistributionsN is the highest valid value for type Distributions, plus one.
+const DistributionsN Distributions = 5
+
+var _DistributionsValueMap = map[string]Distributions{`Uniform`: 0, `Normal`: 1, `Beta`: 2, `Bimodal`: 3, `Multivariate`: 4}
+
+var _DistributionsDescMap = map[Distributions]string{0: `DistributionUniform draws each belief independently from a uniform distribution from 0 to 1.`, 1: `DistributionNormal draws each belief independently from a normal distribution with mean [ConfigBase.BeliefMean] and standard deviation [ConfigBase.BeliefSD], truncated to the range from 0 to 1.`, 2: `DistributionBeta draws each belief independently from a beta distribution with shape parameters [ConfigBase.BetaA] and [ConfigBase.BetaB].`, 3: `DistributionBimodal draws beliefs from a mixture of two truncated normal distributions with standard deviation [ConfigBase.BeliefSD], centered [ConfigBase.BimodalSeparation] apart around [ConfigBase.BeliefMean]. Each agent belongs to the upper mode with probability [ConfigBase.BimodalWeight], and it belongs to the same mode on all belief axes, like members of two opposing camps.`, 4: `DistributionMultivariate draws beliefs from a multivariate normal distribution with mean [ConfigBase.BeliefMean] on each axis, truncated to the range from 0 to 1. The covariance matrix is [ConfigBase.BeliefCovariance] if it is set, and otherwise it has variance [ConfigBase.BeliefSD]^2 and correlation [ConfigBase.BeliefCorrelation] between every pair of axes. Correlations between axes model ideological constraint.`}
+
+var _DistributionsMap = map[Distributions]string{0: `Uniform`, 1: `Normal`, 2: `Beta`, 3: `Bimodal`, 4: `Multivariate`}
+
+// String returns the string representation of this Distributions value.
+func (i Distributions) String() string { return enums.String(i, _DistributionsMap) }
+
+// SetString sets the Distributions value from its string representation,
+// and returns an error if the string is invalid.
+func (i *Distributions) SetString(s string) error {
+	return enums.SetString(i, s, _DistributionsValueMap, "Distributions")
+}
+
+// Int64 returns the Distributions value as an int64.
+func (i Distributions) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the Distributions value from an int64.
+func (i *Distributions) SetInt64(in int64) { *i = Distributions(in) }
+
+// Desc returns the description of the Distributions value.
+func (i Distributions) Desc() string { return enums.Desc(i, _DistributionsDescMap) }
+
+// DistributionsValues returns all possible values for the type Distributions.
+func DistributionsValues() []Distributions { return _DistributionsValues }
+
+// Values returns all possible values for the type Distributions.
+func (i Distributions) Values() []enums.Enum { return enums.Values(_DistributionsValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i Distributions) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *Distributions) UnmarshalText(text []byte) error {
+	return enums.UnmarshalText(i, text, "Distributions")
+}
+
+var _NetworksValues = []Networks{0, 1, 2, 3, 4}
+
+// NetworksN is the highest valid value for type Networks, plus one.
+const NetworksN Networks = 5
+
+var _NetworksValueMap = map[string]Networks{`None`: 0, `ErdosRenyi`: 1, `WattsStrogatz`: 2, `BarabasiAlbert`: 3, `Spatial`: 4}
+
+var _NetworksDescMap = map[Networks]string{0: `NetworkNone does not generate any social network.`, 1: `NetworkErdosRenyi generates an Erdős–Rényi random graph, in which each pair of agents is connected with the same probability.`, 2: `NetworkWattsStrogatz generates a Watts–Strogatz small-world graph, in which agents start on a ring lattice connected to their nearest neighbors, and then each tie is rewired with probability [ConfigBase.NetworkRewire].`, 3: `NetworkBarabasiAlbert generates a Barabási–Albert scale-free graph, in which each agent is connected to existing agents with probability proportional to their degree (preferential attachment).`, 4: `NetworkSpatial generates a spatial proximity graph, in which agents are connected to all other agents within a distance that gives the configured mean degree.`}
+
+var _NetworksMap = map[Networks]string{0: `None`, 1: `ErdosRenyi`, 2: `WattsStrogatz`, 3: `BarabasiAlbert`, 4: `Spatial`}
+
+// String returns the string representation of this Networks value.
+func (i Networks) String() string { return enums.String(i, _NetworksMap) }
+
+// SetString sets the Networks value from its string representation,
+// and returns an error if the string is invalid.
+func (i *Networks) SetString(s string) error {
+	return enums.SetString(i, s, _NetworksValueMap, "Networks")
+}
+
+// Int64 returns the Networks value as an int64.
+func (i Networks) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the Networks value from an int64.
+func (i *Networks) SetInt64(in int64) { *i = Networks(in) }
+
+// Desc returns the description of the Networks value.
+func (i Networks) Desc() string { return enums.Desc(i, _NetworksDescMap) }
+
+// NetworksValues returns all possible values for the type Networks.
+func NetworksValues() []Networks { return _NetworksValues }
+
+// Values returns all possible values for the type Networks.
+func (i Networks) Values() []enums.Enum { return enums.Values(_NetworksValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i Networks) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *Networks) UnmarshalText(text []byte) error { return enums.UnmarshalText(i, text, "Networks") }
+
+var _InteractionsValues = []Interactions{0, 1, 2}
+
+// InteractionsN is the highest valid value for type Interactions, plus one.
+const InteractionsN Interactions = 3
+
+var _InteractionsValueMap = map[string]Interactions{`Spatial`: 0, `Network`: 1, `Mixed`: 2}
+
+var _InteractionsDescMap = map[Interactions]string{0: `InteractionSpatial has agents interact with all other agents within the spatial distance determined by [ConfigBase.InteractionRadius].`, 1: `InteractionNetwork has agents interact with [ConfigBase.NetworkPartners] of their social network neighbors, chosen with probability proportional to the magnitude of their tie strength.`, 2: `InteractionMixed has agents interact both with the spatial partners of [InteractionSpatial] and the network partners of [InteractionNetwork].`}
+
+var _InteractionsMap = map[Interactions]string{0: `Spatial`, 1: `Network`, 2: `Mixed`}
+
+// String returns the string representation of this Interactions value.
+func (i Interactions) String() string { return enums.String(i, _InteractionsMap) }
+
+// SetString sets the Interactions value from its string representation,
+// and returns an error if the string is invalid.
+func (i *Interactions) SetString(s string) error {
+	return enums.SetString(i, s, _InteractionsValueMap, "Interactions")
+}
+
+// Int64 returns the Interactions value as an int64.
+func (i Interactions) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the Interactions value from an int64.
+func (i *Interactions) SetInt64(in int64) { *i = Interactions(in) }
+
+// Desc returns the description of the Interactions value.
+func (i Interactions) Desc() string { return enums.Desc(i, _InteractionsDescMap) }
+
+// InteractionsValues returns all possible values for the type Interactions.
+func InteractionsValues() []Interactions { return _InteractionsValues }
+
+// Values returns all possible values for the type Interactions.
+func (i Interactions) Values() []enums.Enum { return enums.Values(_InteractionsValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i Interactions) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *Interactions) UnmarshalText(text []byte) error {
+	return enums.UnmarshalText(i, text, "Interactions")
+}
+
+var _RulesValues = []Rules{0, 1, 2, 3, 4}
+
+// RulesN is the highest valid value for type Rules, plus one.
+const RulesN Rules = 5
+
+var _RulesValueMap = map[string]Rules{`ExtremeBias`: 0, `DeffuantWeisbuch`: 1, `HegselmannKrause`: 2, `Voter`: 3, `JagerAmblard`: 4}
+
+var _RulesDescMap = map[Rules]string{0: `RuleExtremeBias is the [ExtremeBiasRule].`, 1: `RuleDeffuantWeisbuch is the [DeffuantWeisbuchRule].`, 2: `RuleHegselmannKrause is the [HegselmannKrauseRule].`, 3: `RuleVoter is the [VoterRule].`, 4: `RuleJagerAmblard is the [JagerAmblardRule].`}
+
+var _RulesMap = map[Rules]string{0: `ExtremeBias`, 1: `DeffuantWeisbuch`, 2: `HegselmannKrause`, 3: `Voter`, 4: `JagerAmblard`}
+
+// String returns the string representation of this Rules value.
+func (i Rules) String() string { return enums.String(i, _RulesMap) }
+
+// SetString sets the Rules value from its string representation,
+// and returns an error if the string is invalid.
+func (i *Rules) SetString(s string) error { return enums.SetString(i, s, _RulesValueMap, "Rules") }
+
+// Int64 returns the Rules value as an int64.
+func (i Rules) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the Rules value from an int64.
+func (i *Rules) SetInt64(in int64) { *i = Rules(in) }
+
+// Desc returns the description of the Rules value.
+func (i Rules) Desc() string { return enums.Desc(i, _RulesDescMap) }
+
+// RulesValues returns all possible values for the type Rules.
+func RulesValues() []Rules { return _RulesValues }
+
+// Values returns all possible values for the type Rules.
+func (i Rules) Values() []enums.Enum { return enums.Values(_RulesValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i Rules) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *Rules) UnmarshalText(text []byte) error { return enums.UnmarshalText(i, text, "Rules") }
+
+var _SchedulesValues = []Schedules{0, 1, 2}
+
+// SchedulesN is the highest valid value for type Schedules, plus one.
+const SchedulesN Schedules = 3
+
+var _SchedulesValueMap = map[string]Schedules{`Asynchronous`: 0, `Synchronous`: 1, `RandomSequential`: 2}
+
+var _SchedulesDescMap = map[Schedules]string{0: `ScheduleAsynchronous updates beliefs in place, in the order of [SimBase.Agents], so agents later in the order see beliefs that were already changed in the same step.`, 1: `ScheduleSynchronous reads beliefs from a snapshot taken at the start of each step and writes the changes to a buffer that is applied at the end of the step, so the order of the agents has no effect.`, 2: `ScheduleRandomSequential updates beliefs in place like [ScheduleAsynchronous], but in a random order of the agents that is shuffled in each step.`}
+
+var _SchedulesMap = map[Schedules]string{0: `Asynchronous`, 1: `Synchronous`, 2: `RandomSequential`}
+
+// String returns the string representation of this Schedules value.
+func (i Schedules) String() string { return enums.String(i, _SchedulesMap) }
+
+// SetString sets the Schedules value from its string representation,
+// and returns an error if the string is invalid.
+func (i *Schedules) SetString(s string) error {
+	return enums.SetString(i, s, _SchedulesValueMap, "Schedules")
+}
+
+// Int64 returns the Schedules value as an int64.
+func (i Schedules) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the Schedules value from an int64.
+func (i *Schedules) SetInt64(in int64) { *i = Schedules(in) }
+
+// Desc returns the description of the Schedules value.
+func (i Schedules) Desc() string { return enums.Desc(i, _SchedulesDescMap) }
+
+// SchedulesValues returns all possible values for the type Schedules.
+func SchedulesValues() []Schedules { return _SchedulesValues }
+
+// Values returns all possible values for the type Schedules.
+func (i Schedules) Values() []enums.Enum { return enums.Values(_SchedulesValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i Schedules) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *Schedules) UnmarshalText(text []byte) error {
+	return enums.UnmarshalText(i, text, "Schedules")
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package abm
+
+import "slices"
+
+// Interaction contains information about an interaction between
+// agents, as passed to [SimBase.OnInteract] callbacks.
+type Interaction struct {
+
+	// Agent is the agent that the interaction is centered on. For a normal
+	// interaction, it is the agent that influences the other agent, and for
+	// a [GroupInteractionRule], it is the agent that is influenced by the others.
+	Agent Agent
+
+	// Others are the other agents in the interaction. There is one other
+	// agent for a normal interaction through [Agent.Interact], and one for each
+	// partner of the agent in a step for a [GroupInteractionRule].
+	Others []Agent
+
+	// Deltas contains the change in the beliefs of each agent on each
+	// belief axis as a result of the interaction, starting with Agent
+	// and then Others in order.
+	Deltas [][]float32
+}
+
+// hooks contains the callbacks registered for simulation events.
+type hooks struct {
+	init         hookList[func(sim Sim)]
+	stepStart    hookList[func(sim Sim)]
+	stepEnd      hookList[func(sim Sim)]
+	interact     hookList[func(in *Interaction)]
+	agentAdded   hookList[func(a Agent)]
+	agentRemoved hookList[func(a Agent)]
+}
+
+// hookList is a list of registered callbacks of type F.
+type hookList[F any] struct {
+
+	// funs are the callbacks, in the order that they were registered.
+	funs []F
+
+	// ids contains the unique ID of each callback, for removing it.
+	ids []int
+
+	// nextID is the ID of the next callback to be registered.
+	nextID int
+}
+
+// add registers the given callback and returns a function that removes it.
+// The slices are replaced rather than modified when removing a callback,
+// so callbacks can be removed while the callbacks are being called.
+func (hl *hookList[F]) add(fun F) func() {
+	id := hl.nextID
+	hl.nextID++
+	hl.funs = append(hl.funs, fun)
+	hl.ids = append(hl.ids, id)
+	return func() {
+		i := slices.Index(hl.ids, id)
+		if i < 0 {
+			return
+		}
+		hl.funs = slices.Delete(slices.Clone(hl.funs), i, i+1)
+		hl.ids = slices.Delete(slices.Clone(hl.ids), i, i+1)
+	}
+}
+
+// OnInit registers the given function to be called at the end of
+// [SimBase.Init], which happens whenever the simulation is reset,
+// and at the end of [SimBase.Restore], once the state is restored.
+// It returns a function that unregisters it.
+func (sb *SimBase) OnInit(fun func(sim Sim)) (remove func()) {
+	return sb.hooks.init.add(fun)
+}
+
+// OnStepStart registers the given function to be called at the
+// start of each [SimBase.Step], before [SimBase.Steps] is incremented.
+// It returns a function that unregisters it.
+func (sb *SimBase) OnStepStart(fun func(sim Sim)) (remove func()) {
+	return sb.hooks.stepStart.add(fun)
+}
+
+// OnStepEnd registers the given function to be called at the
+// end of each [SimBase.Step], after all beliefs have been updated.
+// It returns a function that unregisters it.
+func (sb *SimBase) OnStepEnd(fun func(sim Sim)) (remove func()) {
+	return sb.hooks.stepEnd.add(fun)
+}
+
+// OnInteract registers the given function to be called after each
+// interaction between agents. The [Interaction] is only valid during the call.
+// It returns a function that unregisters it.
+func (sb *SimBase) OnInteract(fun func(in *Interaction)) (remove func()) {
+	return sb.hooks.interact.add(fun)
+}
+
+// OnAgentAdded registers the given function to be called after
+// an agent is added with [SimBase.AddAgent].
+// It returns a function that unregisters it.
+func (sb *SimBase) OnAgentAdded(fun func(a Agent)) (remove func()) {
+	return sb.hooks.agentAdded.add(fun)
+}
+
+// OnAgentRemoved registers the given function to be called after
+// an agent is removed with [SimBase.RemoveAgent].
+// It returns a function that unregisters it.
+func (sb *SimBase) OnAgentRemoved(fun func(a Agent)) (remove func()) {
+	return sb.hooks.agentRemoved.add(fun)
+}
+
+// sendSim calls the given callbacks with the simulation.
+func (sb *SimBase) sendSim(hl *hookList[func(sim Sim)]) {
+	for _, fun := range hl.funs {
+		fun(sb.This)
+	}
+}
+
+// startInteraction returns a copy of the beliefs of the given agents before
+// an interaction, or nil if there are no [SimBase.OnInteract] callbacks.
+func (sb *SimBase) startInteraction(agents ...Agent) [][]float32 {
+	if len(sb.hooks.interact.funs) == 0 {
+		return nil
+	}
+	before := make([][]float32, len(agents))
+	for k, a := range agents {
+		before[k] = slices.Clone(a.Base().Beliefs)
+	}
+	return before
+}
+
+// endInteraction calls the [SimBase.OnInteract] callbacks for an interaction
+// of the given agent with the given others, using the beliefs before the
+// interaction from [SimBase.startInteraction].
+func (sb *SimBase) endInteraction(before [][]float32, agent Agent, others ...Agent) {
+	if before == nil {
+		return
+	}
+	in := &Interaction{Agent: agent, Others: others, Deltas: before}
+	for k, a := range append([]Agent{agent}, others...) {
+		for i, b := range a.Base().Beliefs {
+			in.Deltas[k][i] = b - in.Deltas[k][i]
+		}
+	}
+	for _, fun := range sb.hooks.interact.funs {
+		fun(in)
+	}
+}
+
+// AddAgent initializes the given agent in the simulation and adds it to
+// [SimBase.Agents]. It must not be called during [SimBase.Step].
+func (sb *SimBase) AddAgent(a Agent) {
+	a.Init(sb.This)
+	sb.Agents = append(sb.Agents, a)
+	sb.indexByID[a.Base().ID] = len(sb.Agents) - 1
+	for _, fun := range sb.hooks.agentAdded.funs {
+		fun(a)
+	}
+}
+
+// RemoveAgent removes the given agent from [SimBase.Agents], along with
+// all of its ties in the social network. It must not be called during
+// [SimBase.Step]. It does nothing if the agent is not in the simulation.
+func (sb *SimBase) RemoveAgent(a Agent) {
+	ab := a.Base()
+	_, i := sb.AgentByID(ab.ID)
+	if i < 0 {
+		return
+	}
+	for id := range ab.Connections {
+		if other, _ := sb.AgentByID(id); other != nil {
+			delete(other.Base().Connections, ab.ID)
+			other.Base().neighbors = nil
+		}
+	}
+	clear(ab.Connections)
+	ab.neighbors = nil
+	sb.Agents = slices.Delete(sb.Agents, i, i+1)
+	sb.UpdateIndex()
+	for _, fun := range sb.hooks.agentRemoved.funs {
+		fun(a)
+	}
+}
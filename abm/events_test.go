@@ -0,0 +1,29 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package abm
+
+import "testing"
+
+func TestRemoveHooks(t *testing.T) {
+	s := newTestSim(t, 10, nil)
+	var a, b int
+	removeA := s.OnStepEnd(func(sim Sim) { a++ })
+	var removeB func()
+	removeB = s.OnStepEnd(func(sim Sim) {
+		b++
+		removeB() // removing a callback while it is being called
+	})
+	s.Step()
+	s.Step()
+	if a != 2 || b != 1 {
+		t.Fatalf("got %d and %d calls, want 2 and 1", a, b)
+	}
+	removeA()
+	removeA() // removing twice does nothing
+	s.Step()
+	if a != 2 {
+		t.Fatalf("got %d calls after removing the callback, want 2", a)
+	}
+}
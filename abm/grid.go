@@ -0,0 +1,140 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package abm
+
+import (
+	"slices"
+
+	"cogentcore.org/core/math32"
+)
+
+// maxGridSize is the maximum number of cells along each axis of a [Grid].
+const maxGridSize = 4096
+
+// Grid is a uniform grid spatial index over the unit square of
+// agent positions. It is used to efficiently find all agents within
+// a given distance of an agent, without comparing every pair of agents.
+type Grid struct {
+
+	// Boundary is the boundary condition of the simulation space,
+	// which determines how distances are computed.
+	Boundary Boundaries
+
+	// Size is the number of cells along each axis.
+	Size int
+
+	// Cells contains the indices of the agents in each cell,
+	// in row-major order.
+	Cells [][]int
+
+	// cellOf is the index of the cell that each agent is in.
+	cellOf []int
+}
+
+// Build rebuilds the grid for the given agents, with cells that are
+// at least as large as the given query radius. There are at most about
+// as many cells as agents, so that a small radius does not make the grid
+// much larger than the population. If the radius is not positive, only
+// agents at the same position are neighbors, so the cells can be any size.
+func (g *Grid) Build(agents []Agent, radius float32) {
+	g.Size = max(int(math32.Ceil(math32.Sqrt(float32(len(agents))))), 1)
+	if radius > 0 {
+		g.Size = min(g.Size, int(1/radius))
+	}
+	g.Size = min(max(g.Size, 1), maxGridSize)
+	nc := g.Size * g.Size
+	if cap(g.Cells) >= nc {
+		g.Cells = g.Cells[:nc]
+	} else {
+		g.Cells = make([][]int, nc)
+	}
+	for i := range g.Cells {
+		g.Cells[i] = g.Cells[i][:0]
+	}
+	g.cellOf = slices.Grow(g.cellOf[:0], len(agents))[:len(agents)]
+	for i, a := range agents {
+		c := g.cell(a.Base().Position)
+		g.Cells[c] = append(g.Cells[c], i)
+		g.cellOf[i] = c
+	}
+}
+
+// Move updates the grid for the agent at the given index
+// having moved to the given position.
+func (g *Grid) Move(i int, pos math32.Vector2) {
+	c := g.cell(pos)
+	oc := g.cellOf[i]
+	if c == oc {
+		return
+	}
+	cell := g.Cells[oc]
+	k := slices.Index(cell, i)
+	cell[k] = cell[len(cell)-1]
+	g.Cells[oc] = cell[:len(cell)-1]
+	g.Cells[c] = append(g.Cells[c], i)
+	g.cellOf[i] = c
+}
+
+// Neighbors appends to dst the indices of all agents other than the
+// agent at index i that are within the given squared distance of it,
+// in ascending order, and returns the result. The radius must not be larger
+// than the radius that the grid was built with.
+func (g *Grid) Neighbors(agents []Agent, i int, radiusSq float32, dst []int) []int {
+	pos := agents[i].Base().Position
+	c := g.cellOf[i]
+	var xs, ys [3]int
+	nx, ny := 1, 1
+	xs[0], ys[0] = c%g.Size, c/g.Size
+	if radiusSq > 0 || g.Boundary == BoundaryTorus {
+		// agents at the same position are in the same cell,
+		// except on opposite edges of a torus
+		nx = g.adjacent(c%g.Size, &xs)
+		ny = g.adjacent(c/g.Size, &ys)
+	}
+	start := len(dst)
+	for _, y := range ys[:ny] {
+		for _, x := range xs[:nx] {
+			for _, j := range g.Cells[y*g.Size+x] {
+				if j == i {
+					continue
+				}
+				if g.Boundary.DistanceSquared(pos, agents[j].Base().Position) > radiusSq {
+					continue
+				}
+				dst = append(dst, j)
+			}
+		}
+	}
+	slices.Sort(dst[start:])
+	return dst
+}
+
+// adjacent sets the distinct cell coordinates on one axis that are
+// adjacent to (or the same as) the given coordinate, wrapping around
+// for [BoundaryTorus], and returns the number of them.
+func (g *Grid) adjacent(c int, dst *[3]int) int {
+	n := 0
+	for d := -1; d <= 1; d++ {
+		x := c + d
+		if g.Boundary == BoundaryTorus {
+			x = (x + g.Size) % g.Size
+		} else if x < 0 || x >= g.Size {
+			continue
+		}
+		if slices.Contains(dst[:n], x) {
+			continue
+		}
+		dst[n] = x
+		n++
+	}
+	return n
+}
+
+// cell returns the index of the cell containing the given position.
+func (g *Grid) cell(pos math32.Vector2) int {
+	x := min(max(int(pos.X*float32(g.Size)), 0), g.Size-1)
+	y := min(max(int(pos.Y*float32(g.Size)), 0), g.Size-1)
+	return y*g.Size + x
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package abm
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"cogentcore.org/core/math32"
+)
+
+// bruteNeighbors returns the indices of all agents other than the agent at
+// index i within the given squared distance of it by comparing every agent.
+func bruteNeighbors(agents []Agent, i int, radiusSq float32, boundary Boundaries) []int {
+	var res []int
+	pos := agents[i].Base().Position
+	for j, a := range agents {
+		if j != i && boundary.DistanceSquared(pos, a.Base().Position) <= radiusSq {
+			res = append(res, j)
+		}
+	}
+	return res
+}
+
+func TestGridNeighbors(t *testing.T) {
+	rnd := rand.New(rand.NewPCG(1, 2))
+	for _, boundary := range []Boundaries{BoundaryClamp, BoundaryTorus} {
+		for _, n := range []int{1, 10, 500} {
+			agents := make([]Agent, n)
+			for i := range agents {
+				agents[i] = &AgentBase{Position: math32.Vec2(rnd.Float32(), rnd.Float32())}
+			}
+			// include agents exactly on the edges and corners
+			if n > 4 {
+				agents[0].Base().Position = math32.Vec2(0, 0)
+				agents[1].Base().Position = math32.Vec2(1, 1)
+				agents[2].Base().Position = math32.Vec2(0, 0.5)
+				agents[3].Base().Position = math32.Vec2(0.999, 0.5)
+			}
+			// agents at the same position are neighbors with a radius of 0
+			if n > 6 {
+				agents[5].Base().Position = agents[6].Base().Position
+			}
+			for _, radius := range []float32{0, 0.01, 0.05, 0.3, 0.7} {
+				g := Grid{Boundary: boundary}
+				g.Build(agents, radius)
+				if cells := g.Size * g.Size; cells > 2*n+1 {
+					t.Fatalf("%v, n=%d, radius=%g: got %d cells for %d agents", boundary, n, radius, cells, n)
+				}
+				for i := range agents {
+					got := g.Neighbors(agents, i, radius*radius, nil)
+					want := bruteNeighbors(agents, i, radius*radius, boundary)
+					if !slices.Equal(got, want) {
+						t.Fatalf("%v, n=%d, radius=%g, agent %d: got neighbors %v, want %v", boundary, n, radius, i, got, want)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestGridMove(t *testing.T) {
+	rnd := rand.New(rand.NewPCG(3, 4))
+	agents := make([]Agent, 300)
+	for i := range agents {
+		agents[i] = &AgentBase{Position: math32.Vec2(rnd.Float32(), rnd.Float32())}
+	}
+	const radius = 0.1
+	for _, boundary := range []Boundaries{BoundaryClamp, BoundaryTorus} {
+		g := Grid{Boundary: boundary}
+		g.Build(agents, radius)
+		for i, a := range agents {
+			a.Base().Position = math32.Vec2(rnd.Float32(), rnd.Float32())
+			g.Move(i, a.Base().Position)
+		}
+		for i := range agents {
+			got := g.Neighbors(agents, i, radius*radius, nil)
+			want := bruteNeighbors(agents, i, radius*radius, boundary)
+			if !slices.Equal(got, want) {
+				t.Fatalf("%v, agent %d after moving: got neighbors %v, want %v", boundary, i, got, want)
+			}
+		}
+	}
+}
+
+func TestGridRadiusZero(t *testing.T) {
+	s := newTestSim(t, 100, func(cb *ConfigBase) {
+		cb.InteractionRadius = 0
+		cb.Network = NetworkSpatial
+		cb.NetworkDegree = 0
+	})
+	s.Step()
+	if s.grid.Size > 10 {
+		t.Fatalf("got a grid of size %d for 100 agents with a radius of 0", s.grid.Size)
+	}
+	for i, a := range s.Agents {
+		if n := len(a.Base().Neighbors()); n != 0 {
+			t.Fatalf("agent %d has %d ties in a spatial network with a radius of 0", i, n)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package abm
+
+import (
+	"maps"
+	"math"
+	"slices"
+
+	"cogentcore.org/core/math32"
+)
+
+// Networks are the different types of social networks that
+// can be generated between the agents in a simulation.
+type Networks int32 //enums:enum -trim-prefix Network
+
+const (
+
+	// NetworkNone does not generate any social network.
+	NetworkNone Networks = iota
+
+	// NetworkErdosRenyi generates an Erdős–Rényi random graph, in which
+	// each pair of agents is connected with the same probability.
+	NetworkErdosRenyi
+
+	// NetworkWattsStrogatz generates a Watts–Strogatz small-world graph,
+	// in which agents start on a ring lattice connected to their nearest
+	// neighbors, and then each tie is rewired with probability [ConfigBase.NetworkRewire].
+	NetworkWattsStrogatz
+
+	// NetworkBarabasiAlbert generates a Barabási–Albert scale-free graph,
+	// in which each agent is connected to existing agents with probability
+	// proportional to their degree (preferential attachment).
+	NetworkBarabasiAlbert
+
+	// NetworkSpatial generates a spatial proximity graph, in which
+	// agents are connected to all other agents within a distance
+	// that gives the configured mean degree.
+	NetworkSpatial
+)
+
+// Connect connects the agent with the given other agent with the given
+// tie strength (-1 to 1), replacing any existing tie between them.
+// Ties are undirected, so both agents are updated.
+func (ab *AgentBase) Connect(other Agent, strength float32) {
+	ob := other.Base()
+	if ab.Connections == nil {
+		ab.Connections = map[uint64]float32{}
+	}
+	if ob.Connections == nil {
+		ob.Connections = map[uint64]float32{}
+	}
+	ab.Connections[ob.ID] = strength
+	ob.Connections[ab.ID] = strength
+	ab.neighbors, ob.neighbors = nil, nil
+}
+
+// Disconnect removes any tie between the agent and the given other agent.
+func (ab *AgentBase) Disconnect(other Agent) {
+	ob := other.Base()
+	delete(ab.Connections, ob.ID)
+	delete(ob.Connections, ab.ID)
+	ab.neighbors, ob.neighbors = nil, nil
+}
+
+// IsConnected returns whether the agent has a tie with the given other agent.
+func (ab *AgentBase) IsConnected(other Agent) bool {
+	_, ok := ab.Connections[other.Base().ID]
+	return ok
+}
+
+// Neighbors returns the IDs of all agents that the agent has a tie with,
+// in ascending order. The result is cached until the ties of the agent
+// change, so it must not be modified.
+func (ab *AgentBase) Neighbors() []uint64 {
+	if ab.neighbors == nil {
+		ab.neighbors = slices.Sorted(maps.Keys(ab.Connections))
+	}
+	return ab.neighbors
+}
+
+// Degree returns the number of ties that the agent has.
+func (ab *AgentBase) Degree() int {
+	return len(ab.Connections)
+}
+
+// ClearNetwork removes all ties between the agents in the simulation.
+func (sb *SimBase) ClearNetwork() {
+	for _, a := range sb.Agents {
+		clear(a.Base().Connections)
+		a.Base().neighbors = nil
+	}
+}
+
+// InitNetwork generates the social network of the simulation
+// according to [ConfigBase.Network], removing any existing ties.
+func (sb *SimBase) InitNetwork() {
+	cb := sb.Config.Base()
+	sb.ClearNetwork()
+	n := len(sb.Agents)
+	if n < 2 {
+		return
+	}
+	k := cb.NetworkDegree
+	switch cb.Network {
+	case NetworkErdosRenyi:
+		sb.ErdosRenyi(float32(k) / float32(n-1))
+	case NetworkWattsStrogatz:
+		sb.WattsStrogatz(k, cb.NetworkRewire)
+	case NetworkBarabasiAlbert:
+		sb.BarabasiAlbert(max(k/2, 1))
+	case NetworkSpatial:
+		sb.SpatialNetwork(math32.Sqrt(float32(k) / (math32.Pi * float32(n))))
+	}
+}
+
+// tieStrength returns a random tie strength based on [ConfigBase.NegativeTies].
+func (sb *SimBase) tieStrength() float32 {
+	strength := 1 - sb.Rand.Float32() // (0, 1]
+	if sb.Rand.Float32() < sb.Config.Base().NegativeTies {
+		strength = -strength
+	}
+	return strength
+}
+
+// ErdosRenyi connects each pair of agents with the given probability.
+// It uses geometric skipping, so it runs in time proportional to the
+// number of ties rather than the number of pairs.
+func (sb *SimBase) ErdosRenyi(p float32) {
+	n := len(sb.Agents)
+	if p <= 0 {
+		return
+	}
+	if p >= 1 {
+		for v := 1; v < n; v++ {
+			for w := range v {
+				sb.Agents[v].Base().Connect(sb.Agents[w], sb.tieStrength())
+			}
+		}
+		return
+	}
+	lp := math.Log(1 - float64(p))
+	v, w := 1, -1
+	for v < n {
+		w += 1 + int(math.Log(1-sb.Rand.Float64())/lp)
+		for w >= v && v < n {
+			w -= v
+			v++
+		}
+		if v < n {
+			sb.Agents[v].Base().Connect(sb.Agents[w], sb.tieStrength())
+		}
+	}
+}
+
+// WattsStrogatz places the agents on a ring lattice in which each agent
+// is connected to its k nearest neighbors (k/2 on each side), and then
+// rewires the far end of each tie to a random agent with the given probability.
+func (sb *SimBase) WattsStrogatz(k int, rewire float32) {
+	n := len(sb.Agents)
+	half := min(k/2, (n-1)/2)
+	for d := 1; d <= half; d++ {
+		for i := range n {
+			a := sb.Agents[i].Base()
+			j := (i + d) % n
+			if sb.Rand.Float32() < rewire {
+				// avoid self-ties and duplicate ties; give up if a
+				// free target is not found after n attempts
+				for range n {
+					t := sb.Rand.IntN(n)
+					if t != i && !a.IsConnected(sb.Agents[t]) {
+						j = t
+						break
+					}
+				}
+			}
+			if j == i || a.IsConnected(sb.Agents[j]) {
+				continue
+			}
+			a.Connect(sb.Agents[j], sb.tieStrength())
+		}
+	}
+}
+
+// BarabasiAlbert grows a scale-free network by preferential attachment,
+// starting from a complete graph of m+1 agents and then connecting each
+// subsequent agent to m distinct existing agents chosen with probability
+// proportional to their degree.
+func (sb *SimBase) BarabasiAlbert(m int) {
+	n := len(sb.Agents)
+	m = min(m, n-1)
+	// targets contains each agent index once for every tie it has,
+	// so that uniform sampling from it is proportional to degree
+	var targets []int
+	for v := 1; v <= m; v++ {
+		for w := range v {
+			sb.Agents[v].Base().Connect(sb.Agents[w], sb.tieStrength())
+			targets = append(targets, v, w)
+		}
+	}
+	chosen := make([]int, 0, m)
+	for v := m + 1; v < n; v++ {
+		a := sb.Agents[v].Base()
+		chosen = chosen[:0]
+		for len(chosen) < m {
+			w := targets[sb.Rand.IntN(len(targets))]
+			if slices.Contains(chosen, w) {
+				continue
+			}
+			chosen = append(chosen, w)
+			a.Connect(sb.Agents[w], sb.tieStrength())
+		}
+		for _, w := range chosen {
+			targets = append(targets, v, w)
+		}
+	}
+}
+
+// SpatialNetwork connects each pair of agents whose spatial positions
+// are within the given distance of each other, as determined by
+// [ConfigBase.Boundary].
+func (sb *SimBase) SpatialNetwork(radius float32) {
+	grid := Grid{Boundary: sb.Config.Base().Boundary}
+	grid.Build(sb.Agents, radius)
+	var neighbors []int
+	for i, a := range sb.Agents {
+		neighbors = grid.Neighbors(sb.Agents, i, radius*radius, neighbors[:0])
+		for _, j := range neighbors {
+			if j < i {
+				continue
+			}
+			a.Base().Connect(sb.Agents[j], sb.tieStrength())
+		}
+	}
+}
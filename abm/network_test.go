@@ -0,0 +1,137 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package abm
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"cogentcore.org/core/math32"
+)
+
+func TestNeighborsCache(t *testing.T) {
+	s := newTestSim(t, 4, nil)
+	a, b, c, d := s.Agents[0].Base(), s.Agents[1], s.Agents[2], s.Agents[3]
+	check := func(want ...uint64) {
+		t.Helper()
+		if got := a.Neighbors(); !slices.Equal(got, want) {
+			t.Fatalf("got neighbors %v, want %v", got, want)
+		}
+	}
+	check()
+	a.Connect(d, 1)
+	a.Connect(b, 0.5)
+	check(1, 3)
+	c.Base().Connect(a, -1)
+	check(1, 2, 3)
+	a.Disconnect(b)
+	check(2, 3)
+	s.RemoveAgent(d)
+	check(2)
+	s.ClearNetwork()
+	check()
+}
+
+// checkNetwork fails the test if the ties of the given simulation are not
+// symmetric, include self-ties, or include agents not in the simulation.
+// It returns the number of ties and the number of negative ties.
+func checkNetwork(t *testing.T, s *testSim) (ties, negative int) {
+	t.Helper()
+	for _, a := range s.Agents {
+		ab := a.Base()
+		for id, strength := range ab.Connections {
+			if id == ab.ID {
+				t.Fatalf("agent %d has a tie with itself", id)
+			}
+			other, _ := s.AgentByID(id)
+			if other == nil {
+				t.Fatalf("agent %d has a tie with missing agent %d", ab.ID, id)
+			}
+			if back, ok := other.Base().Connections[ab.ID]; !ok || back != strength {
+				t.Fatalf("tie from agent %d to %d with strength %g is not symmetric", ab.ID, id, strength)
+			}
+			if strength == 0 || strength < -1 || strength > 1 {
+				t.Fatalf("tie from agent %d to %d has strength %g", ab.ID, id, strength)
+			}
+			if id < ab.ID {
+				continue
+			}
+			ties++
+			if strength < 0 {
+				negative++
+			}
+		}
+	}
+	return ties, negative
+}
+
+func TestNetworks(t *testing.T) {
+	const n, k = 2000, 6
+	for _, network := range []Networks{NetworkErdosRenyi, NetworkWattsStrogatz, NetworkBarabasiAlbert, NetworkSpatial} {
+		t.Run(network.String(), func(t *testing.T) {
+			s := newTestSim(t, n, func(cb *ConfigBase) {
+				cb.Network = network
+				cb.NetworkDegree = k
+				cb.NegativeTies = 0.3
+			})
+			ties, negative := checkNetwork(t, s)
+			if mean := 2 * float64(ties) / n; math.Abs(mean-k) > 0.3 {
+				t.Errorf("got mean degree %g, want about %d", mean, k)
+			}
+			if frac := float64(negative) / float64(ties); math.Abs(frac-0.3) > 0.03 {
+				t.Errorf("got %g negative ties, want about 0.3", frac)
+			}
+		})
+	}
+}
+
+func TestNetworkTieCounts(t *testing.T) {
+	// duplicate ties would replace existing ones, giving fewer ties
+	const n = 500
+	s := newTestSim(t, n, nil)
+	s.BarabasiAlbert(3)
+	if ties, _ := checkNetwork(t, s); ties != 3*4/2+(n-4)*3 {
+		t.Errorf("BarabasiAlbert: got %d ties, want %d", ties, 3*4/2+(n-4)*3)
+	}
+	s.ClearNetwork()
+	s.WattsStrogatz(6, 0)
+	if ties, _ := checkNetwork(t, s); ties != n*3 {
+		t.Errorf("WattsStrogatz: got %d ties, want %d", ties, n*3)
+	}
+	s.ClearNetwork()
+	s.ErdosRenyi(1)
+	if ties, _ := checkNetwork(t, s); ties != n*(n-1)/2 {
+		t.Errorf("ErdosRenyi: got %d ties, want %d", ties, n*(n-1)/2)
+	}
+}
+
+func TestNetworkPartners(t *testing.T) {
+	s := newTestSim(t, 300, func(cb *ConfigBase) {
+		cb.Interaction = InteractionMixed
+		cb.Network = NetworkErdosRenyi
+		cb.NetworkPartners = 4
+		cb.InteractionRadius = 5
+	})
+	ir := s.Config.Base().InteractionRadius / float32(len(s.Agents))
+	s.grid.Build(s.Agents, math32.Sqrt(ir))
+	for i, a := range s.Agents {
+		partners := s.partners(i, ir, s.Rand, nil)
+		sorted := slices.Sorted(slices.Values(partners))
+		if len(slices.Compact(sorted)) != len(partners) {
+			t.Fatalf("agent %d has duplicate partners %v", i, partners)
+		}
+		spatial := len(s.grid.Neighbors(s.Agents, i, ir, nil))
+		others := 0
+		for _, id := range a.Base().Neighbors() {
+			if _, j := s.AgentByID(id); !slices.Contains(partners[:spatial], j) {
+				others++
+			}
+		}
+		if got, want := len(partners)-spatial, min(others, 4); got != want {
+			t.Fatalf("agent %d has %d network partners, want %d", i, got, want)
+		}
+	}
+}
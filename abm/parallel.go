@@ -0,0 +1,85 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package abm
+
+import (
+	"math/rand/v2"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"cogentcore.org/core/math32"
+)
+
+// blockSize is the number of agents in each block of a parallel step.
+// Each block has its own random number stream, so the results of a
+// parallel step depend on the block size but not on the number of workers.
+const blockSize = 256
+
+// stepParallel advances the simulation by one time step in parallel,
+// as specified by [ConfigBase.Parallel]. The agents are split into blocks
+// of [blockSize] agents, and each block is processed by a worker with a
+// random number stream seeded from [SimBase.Rand] and the block index.
+// First, all agents move and apply their values. Then, the interactions
+// are selected in parallel based on the resulting positions and beliefs.
+// Finally, the interactions are applied in the agent order determined by
+// [ConfigBase.Schedule], which deterministically resolves multiple
+// interactions with the same agent.
+func (sb *SimBase) stepParallel() {
+	cb := sb.Config.Base()
+	n := len(sb.Agents)
+	ir := cb.InteractionRadius / float32(n)
+	nblocks := (n + blockSize - 1) / blockSize
+	seed := sb.Rand.Uint64()
+	streams := make([]*rand.Rand, nblocks)
+	for b := range streams {
+		streams[b] = rand.New(rand.NewPCG(seed, uint64(b)))
+	}
+
+	sb.parallelBlocks(nblocks, func(b int) {
+		for i := b * blockSize; i < min((b+1)*blockSize, n); i++ {
+			a := sb.Agents[i]
+			a.StepPosition(streams[b])
+			a.ApplyValues()
+		}
+	})
+
+	sb.takeSnapshot()
+	sb.grid.Boundary = cb.Boundary
+	sb.grid.Build(sb.Agents, math32.Sqrt(ir))
+	partners := make([][]int, n)
+	sb.parallelBlocks(nblocks, func(b int) {
+		for i := b * blockSize; i < min((b+1)*blockSize, n); i++ {
+			partners[i] = sb.partners(i, ir, streams[b], nil)
+			partners[i] = sb.filterPartners(i, partners[i], streams[b])
+		}
+	})
+
+	for _, i := range sb.order {
+		sb.interactAll(i, partners[i])
+	}
+}
+
+// parallelBlocks calls the given function for each block index
+// from 0 to nblocks-1, distributing the blocks across one worker
+// goroutine per available CPU core.
+func (sb *SimBase) parallelBlocks(nblocks int, fun func(b int)) {
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for range min(runtime.GOMAXPROCS(0), nblocks) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				b := int(next.Add(1) - 1)
+				if b >= nblocks {
+					return
+				}
+				fun(b)
+			}
+		}()
+	}
+	wg.Wait()
+}
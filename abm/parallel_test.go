@@ -0,0 +1,40 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package abm
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestParallelGOMAXPROCS(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(0))
+	for _, schedule := range SchedulesValues() {
+		for _, interaction := range InteractionsValues() {
+			configure := func(cb *ConfigBase) {
+				cb.Parallel = true
+				cb.Schedule = schedule
+				cb.Interaction = interaction
+				cb.Network = NetworkWattsStrogatz
+				cb.InteractionRadius = 5
+			}
+			var want *testSim
+			for _, procs := range []int{1, 2, 7} {
+				runtime.GOMAXPROCS(procs)
+				s := newTestSim(t, 1000, configure)
+				for range 10 {
+					s.Step()
+				}
+				if want == nil {
+					want = s
+					continue
+				}
+				t.Run(schedule.String()+"/"+interaction.String(), func(t *testing.T) {
+					checkSameAgents(t, s, want)
+				})
+			}
+		}
+	}
+}
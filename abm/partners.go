@@ -0,0 +1,96 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package abm
+
+import (
+	"math/rand/v2"
+	"slices"
+
+	"cogentcore.org/core/math32"
+)
+
+// Interactions are the different ways in which agents
+// choose their interaction partners.
+type Interactions int32 //enums:enum -trim-prefix Interaction
+
+const (
+
+	// InteractionSpatial has agents interact with all other agents
+	// within the spatial distance determined by [ConfigBase.InteractionRadius].
+	InteractionSpatial Interactions = iota
+
+	// InteractionNetwork has agents interact with [ConfigBase.NetworkPartners]
+	// different social network neighbors, chosen with probability proportional
+	// to the magnitude of their tie strength.
+	InteractionNetwork
+
+	// InteractionMixed has agents interact both with the spatial partners
+	// of [InteractionSpatial] and the network partners of [InteractionNetwork],
+	// with network partners chosen from the neighbors that are not already
+	// spatial partners, so that no agent is a partner twice in a step.
+	InteractionMixed
+)
+
+// partners appends to dst the indices of the candidate interaction partners
+// for the agent at index i according to [ConfigBase.Interaction], using the
+// given squared interaction radius and random number generator, and
+// returns the result. [SimBase.grid] must be up to date.
+func (sb *SimBase) partners(i int, ir float32, rnd *rand.Rand, dst []int) []int {
+	mode := sb.Config.Base().Interaction
+	if mode != InteractionNetwork {
+		dst = sb.grid.Neighbors(sb.Agents, i, ir, dst)
+	}
+	if mode != InteractionSpatial {
+		dst = sb.networkPartners(i, rnd, dst)
+	}
+	return dst
+}
+
+// networkPartners appends to dst the indices of [ConfigBase.NetworkPartners]
+// randomly chosen network neighbors of the agent at index i, with the
+// probability of each neighbor being chosen proportional to the magnitude
+// of its tie strength, and returns the result. Neighbors are chosen without
+// replacement, and neighbors that are already in dst (such as the spatial
+// partners for [InteractionMixed]) are not chosen, so there are fewer
+// partners if there are not enough other neighbors with nonzero ties.
+func (sb *SimBase) networkPartners(i int, rnd *rand.Rand, dst []int) []int {
+	ab := sb.Agents[i].Base()
+	ids := ab.Neighbors()
+	// weight returns the sampling weight and agent index of the
+	// neighbor with the given ID, which has a weight of 0 if it
+	// is not in the simulation or has already been chosen
+	weight := func(id uint64) (float32, int) {
+		_, j := sb.AgentByID(id)
+		if j < 0 || slices.Contains(dst, j) {
+			return 0, j
+		}
+		return math32.Abs(ab.Connections[id]), j
+	}
+	for range sb.Config.Base().NetworkPartners {
+		total := float32(0)
+		for _, id := range ids {
+			w, _ := weight(id)
+			total += w
+		}
+		if total == 0 {
+			break
+		}
+		r := rnd.Float32() * total
+		chosen := -1
+		for _, id := range ids {
+			w, j := weight(id)
+			if w == 0 {
+				continue
+			}
+			chosen = j
+			r -= w
+			if r < 0 {
+				break
+			}
+		}
+		dst = append(dst, chosen)
+	}
+	return dst
+}
@@ -0,0 +1,229 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package abm
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// OpenPopulation replaces [SimBase.Agents] with agents read from the given
+// population CSV file, as in [SimBase.ReadPopulation].
+func (sb *SimBase) OpenPopulation(filename string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return sb.ReadPopulation(f)
+}
+
+// ReadPopulation replaces [SimBase.Agents] with agents read from the given
+// population CSV data, such as data derived from a survey.
+//
+// The data must have a header row and then one row per agent. The columns are
+// Belief 0, Belief 1, etc. for the beliefs on each belief axis, which are
+// required, and optionally Value 0, Value 1, etc. for the values on each axis,
+// Influence, Position X, and Position Y. Other columns, such as respondent
+// identifiers, are ignored. All numbers must be from 0 to 1, except for
+// influence, which must not be negative.
+//
+// Each agent is an [AgentBase] that is initialized with [AgentBase.Init], with
+// IDs starting from 0, after which the fields in the data are set. Values are
+// set to the beliefs if they are not given, and the influence and position are
+// left as initialized if they are not given. The number of belief columns (and
+// value columns, if any) must equal [ConfigBase.Beliefs]. The social network is
+// then generated for the new agents as in [SimBase.InitNetwork]. It should be
+// called after [Sim.Init], since that replaces the agents again. If the data
+// is not valid, it returns an error without changing the simulation.
+func (sb *SimBase) ReadPopulation(r io.Reader) error {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return fmt.Errorf("abm.SimBase.ReadPopulation: missing header row")
+	}
+	nb := sb.Config.Base().Beliefs
+	header := records[0]
+	cols := map[string]int{}
+	for i, name := range header {
+		cols[strings.TrimSpace(name)] = i
+	}
+	beliefCols, err := axisColumns(cols, "Belief", nb)
+	if err != nil {
+		return err
+	}
+	var valueCols []int
+	if _, ok := cols["Value 0"]; ok {
+		valueCols, err = axisColumns(cols, "Value", nb)
+		if err != nil {
+			return err
+		}
+	}
+
+	// parse and validate every row before changing the simulation,
+	// so that an error leaves the current agents in place
+	type row struct {
+		beliefs, values []float32
+		influence, x, y float32
+	}
+	influenceCol, hasInfluence := cols["Influence"]
+	xCol, hasX := cols["Position X"]
+	yCol, hasY := cols["Position Y"]
+	rows := make([]row, len(records)-1)
+	for k, record := range records[1:] {
+		line := k + 2 // 1-based, after the header
+		get := func(col int, max float32) (float32, error) {
+			s := strings.TrimSpace(record[col])
+			v, err := strconv.ParseFloat(s, 32)
+			if err != nil {
+				return 0, fmt.Errorf("abm.SimBase.ReadPopulation: row %d, column %s: invalid number %q", line, header[col], s)
+			}
+			if v < 0 || v > float64(max) {
+				return 0, fmt.Errorf("abm.SimBase.ReadPopulation: row %d, column %s: %v is not between 0 and %v", line, header[col], v, max)
+			}
+			return float32(v), nil
+		}
+		r := &rows[k]
+		r.beliefs = make([]float32, nb)
+		for i, col := range beliefCols {
+			if r.beliefs[i], err = get(col, 1); err != nil {
+				return err
+			}
+		}
+		if valueCols != nil {
+			r.values = make([]float32, nb)
+			for i, col := range valueCols {
+				if r.values[i], err = get(col, 1); err != nil {
+					return err
+				}
+			}
+		}
+		if hasInfluence {
+			if r.influence, err = get(influenceCol, float32(math.Inf(1))); err != nil {
+				return err
+			}
+		}
+		if hasX {
+			if r.x, err = get(xCol, 1); err != nil {
+				return err
+			}
+		}
+		if hasY {
+			if r.y, err = get(yCol, 1); err != nil {
+				return err
+			}
+		}
+	}
+
+	sb.idCounter = 0
+	agents := make([]Agent, len(rows))
+	for k, r := range rows {
+		ab := &AgentBase{}
+		ab.Init(sb.This)
+		ab.Beliefs = r.beliefs
+		if r.values != nil {
+			ab.Values = r.values
+		} else {
+			copy(ab.Values, r.beliefs)
+		}
+		if hasInfluence {
+			ab.Influence = r.influence
+		}
+		if hasX {
+			ab.Position.X = r.x
+		}
+		if hasY {
+			ab.Position.Y = r.y
+		}
+		agents[k] = ab
+	}
+	sb.Agents = agents
+	sb.UpdateIndex()
+	sb.InitNetwork()
+	return nil
+}
+
+// axisColumns returns the indexes of the columns named with the given prefix
+// followed by the index of each belief axis, checking that there are exactly
+// nb columns named with the prefix followed by an integer.
+func axisColumns(cols map[string]int, prefix string, nb int) ([]int, error) {
+	n := 0
+	for name := range cols {
+		index, ok := strings.CutPrefix(name, prefix+" ")
+		if !ok {
+			continue
+		}
+		if _, err := strconv.Atoi(index); err == nil {
+			n++
+		}
+	}
+	if n != nb {
+		return nil, fmt.Errorf("abm.SimBase.ReadPopulation: population has %d %s columns, but Beliefs is %d", n, prefix, nb)
+	}
+	res := make([]int, nb)
+	for i := range nb {
+		name := fmt.Sprintf("%s %d", prefix, i)
+		col, ok := cols[name]
+		if !ok {
+			return nil, fmt.Errorf("abm.SimBase.ReadPopulation: missing column %s", name)
+		}
+		res[i] = col
+	}
+	return res, nil
+}
+
+// SavePopulation saves the current agents to the given population
+// CSV file, as in [SimBase.WritePopulation].
+func (sb *SimBase) SavePopulation(filename string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := sb.WritePopulation(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// WritePopulation writes the current agents as population CSV data with all
+// of the columns, which can be read with [SimBase.ReadPopulation].
+func (sb *SimBase) WritePopulation(w io.Writer) error {
+	nb := sb.Config.Base().Beliefs
+	var header []string
+	for i := range nb {
+		header = append(header, fmt.Sprintf("Belief %d", i))
+	}
+	for i := range nb {
+		header = append(header, fmt.Sprintf("Value %d", i))
+	}
+	header = append(header, "Influence", "Position X", "Position Y")
+	cw := csv.NewWriter(w)
+	cw.Write(header)
+	format := func(v float32) string {
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	}
+	record := make([]string, len(header))
+	for _, a := range sb.Agents {
+		ab := a.Base()
+		for i := range nb {
+			record[i] = format(ab.Beliefs[i])
+			record[nb+i] = format(ab.Values[i])
+		}
+		record[2*nb] = format(ab.Influence)
+		record[2*nb+1] = format(ab.Position.X)
+		record[2*nb+2] = format(ab.Position.Y)
+		cw.Write(record)
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package abm
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestReadPopulation(t *testing.T) {
+	s := newTestSim(t, 10, func(cb *ConfigBase) { cb.Beliefs = 2 })
+	want := newTestSim(t, 10, func(cb *ConfigBase) { cb.Beliefs = 2 })
+	bad := "Belief 0,Belief 1\n0.1,0.2\n0.3,x\n"
+	if err := s.ReadPopulation(strings.NewReader(bad)); err == nil {
+		t.Fatal("got no error for an invalid number")
+	}
+	checkSameAgents(t, s, want)
+	s.Step()
+	want.Step()
+	checkSameAgents(t, s, want)
+
+	var buf bytes.Buffer
+	if err := want.WritePopulation(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.ReadPopulation(&buf); err != nil {
+		t.Fatal(err)
+	}
+	got, wa := agentStates(s), agentStates(want)
+	for i := range got {
+		g, w := &got[i], &wa[i]
+		if g.ID != uint64(i) || g.Influence != w.Influence || g.Position != w.Position ||
+			!slices.Equal(g.Beliefs, w.Beliefs) || !slices.Equal(g.Values, w.Values) {
+			t.Fatalf("agent %d was not read back: got %+v, want %+v", i, *g, *w)
+		}
+	}
+}
+
+func TestReadPopulationColumns(t *testing.T) {
+	s := newTestSim(t, 10, func(cb *ConfigBase) { cb.Beliefs = 2 })
+	data := "Respondent,Belief 0,Belief 1,Belief notes,Value 0,Value 1\nr1,0.1,0.2,none,0.3,0.4\n"
+	if err := s.ReadPopulation(strings.NewReader(data)); err != nil {
+		t.Fatal(err)
+	}
+	ab := s.Agents[0].Base()
+	if len(s.Agents) != 1 || !slices.Equal(ab.Beliefs, []float32{0.1, 0.2}) || !slices.Equal(ab.Values, []float32{0.3, 0.4}) {
+		t.Fatalf("got agents %v, want one with beliefs [0.1 0.2] and values [0.3 0.4]", agentStates(s))
+	}
+	if err := s.ReadPopulation(strings.NewReader("Belief 0,Belief 1,Belief 2\n0.1,0.2,0.3\n")); err == nil {
+		t.Error("got no error for 3 belief columns with 2 belief axes")
+	}
+}
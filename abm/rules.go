@@ -0,0 +1,179 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package abm
+
+import "cogentcore.org/core/math32"
+
+// InteractionRule is the interface for rules that determine how
+// the beliefs of an agent change when it interacts with another agent.
+// The rule of a simulation is [SimBase.Rule], which is set from
+// [ConfigBase.Rule] in [SimBase.Init]; simulations can set it to
+// a custom rule after calling [SimBase.Init].
+type InteractionRule interface {
+
+	// Update updates the beliefs of the target agent as a result
+	// of interacting with the source agent. The beliefs are clamped
+	// to the valid range afterward, so Update does not need to clamp them.
+	Update(target, source *AgentBase, cb *ConfigBase)
+}
+
+// GroupInteractionRule is an [InteractionRule] that updates the beliefs of
+// an agent based on all of the agents that it interacts with in a step
+// at once, instead of one interaction at a time.
+//
+// The direction of influence is the opposite of that for other rules: with a
+// group rule, each agent is updated from its partners in a step through
+// [Agent.InteractGroup], while with other rules, each agent updates its
+// partners through [Agent.Interact] and [Agent.Receive]. Agent types that
+// override [Agent.Receive] should therefore also override [Agent.InteractGroup]
+// to support group rules.
+type GroupInteractionRule interface {
+	InteractionRule
+
+	// UpdateGroup updates the beliefs of the target agent as a result
+	// of interacting with all of the given source agents.
+	UpdateGroup(target *AgentBase, sources []*AgentBase, cb *ConfigBase)
+}
+
+// Rules are the built-in [InteractionRule]s.
+type Rules int32 //enums:enum -trim-prefix Rule
+
+const (
+
+	// RuleExtremeBias is the [ExtremeBiasRule].
+	RuleExtremeBias Rules = iota
+
+	// RuleDeffuantWeisbuch is the [DeffuantWeisbuchRule].
+	RuleDeffuantWeisbuch
+
+	// RuleHegselmannKrause is the [HegselmannKrauseRule].
+	RuleHegselmannKrause
+
+	// RuleVoter is the [VoterRule].
+	RuleVoter
+
+	// RuleJagerAmblard is the [JagerAmblardRule].
+	RuleJagerAmblard
+)
+
+// Rule returns a new [InteractionRule] of the type of the rule.
+func (r Rules) Rule() InteractionRule {
+	switch r {
+	case RuleDeffuantWeisbuch:
+		return &DeffuantWeisbuchRule{}
+	case RuleHegselmannKrause:
+		return &HegselmannKrauseRule{}
+	case RuleVoter:
+		return &VoterRule{}
+	case RuleJagerAmblard:
+		return &JagerAmblardRule{}
+	}
+	return &ExtremeBiasRule{}
+}
+
+// BeliefDistance returns the normalized distance between the beliefs
+// of the given agents, which is the root mean square of the differences
+// on each belief axis (0 to 1).
+func BeliefDistance(a, b *AgentBase) float32 {
+	dist := float32(0)
+	for i, ba := range a.Beliefs {
+		delta := b.Beliefs[i] - ba
+		dist += delta * delta
+	}
+	return math32.Sqrt(dist / float32(len(a.Beliefs)))
+}
+
+// ExtremeBiasRule shifts the beliefs of the target toward the beliefs of the
+// source in proportion to [ConfigBase.InteractionEffect] and the ratio of their
+// influences, with a bias toward extreme beliefs set by [ConfigBase.ExtremeBias].
+type ExtremeBiasRule struct{}
+
+func (r *ExtremeBiasRule) Update(target, source *AgentBase, cb *ConfigBase) {
+	for i := range target.Beliefs {
+		bt := &target.Beliefs[i]
+		// The delta is not just based on bs - bt, because talking with someone who
+		// you agree with will move you more strongly in that direction, so it is more
+		// like bs - 0.5. On the other hand, arguments in the middle aren't entirely
+		// un-motivating, just somewhat less persuasive, so the ExtremeBias parameter
+		// determines how much less persuasive they are.
+		baseline := 0.5*cb.ExtremeBias + *bt*(1-cb.ExtremeBias)
+		delta := cb.InteractionEffect * (source.Beliefs[i] - baseline)
+		*bt += delta * (source.Influence / target.Influence)
+	}
+}
+
+// DeffuantWeisbuchRule is the Deffuant–Weisbuch bounded confidence model:
+// if the [BeliefDistance] between the agents is less than [ConfigBase.ConfidenceBound],
+// the target moves toward the source by [ConfigBase.InteractionEffect] times
+// the difference in beliefs. Otherwise, nothing happens.
+type DeffuantWeisbuchRule struct{}
+
+func (r *DeffuantWeisbuchRule) Update(target, source *AgentBase, cb *ConfigBase) {
+	if BeliefDistance(target, source) >= cb.ConfidenceBound {
+		return
+	}
+	for i, bs := range source.Beliefs {
+		target.Beliefs[i] += cb.InteractionEffect * (bs - target.Beliefs[i])
+	}
+}
+
+// HegselmannKrauseRule is the Hegselmann–Krause bounded confidence model:
+// the target adopts the mean beliefs of itself and all source agents within
+// a [BeliefDistance] of [ConfigBase.ConfidenceBound]. When applied to a single
+// interaction, it averages the beliefs of the two agents if they are close enough.
+type HegselmannKrauseRule struct{}
+
+func (r *HegselmannKrauseRule) Update(target, source *AgentBase, cb *ConfigBase) {
+	r.UpdateGroup(target, []*AgentBase{source}, cb)
+}
+
+func (r *HegselmannKrauseRule) UpdateGroup(target *AgentBase, sources []*AgentBase, cb *ConfigBase) {
+	n := 1
+	sum := make([]float32, len(target.Beliefs))
+	copy(sum, target.Beliefs)
+	for _, s := range sources {
+		if BeliefDistance(target, s) >= cb.ConfidenceBound {
+			continue
+		}
+		n++
+		for i, bs := range s.Beliefs {
+			sum[i] += bs
+		}
+	}
+	for i := range target.Beliefs {
+		target.Beliefs[i] = sum[i] / float32(n)
+	}
+}
+
+// VoterRule is the voter model: the target copies the beliefs of the source.
+type VoterRule struct{}
+
+func (r *VoterRule) Update(target, source *AgentBase, cb *ConfigBase) {
+	copy(target.Beliefs, source.Beliefs)
+}
+
+// JagerAmblardRule is the Jager–Amblard social judgment model of assimilation
+// and rejection: if the [BeliefDistance] between the agents is less than
+// [ConfigBase.ConfidenceBound], the target moves toward the source by
+// [ConfigBase.InteractionEffect] times the difference in beliefs (assimilation).
+// If it is greater than [ConfigBase.RejectionBound], the target moves away from
+// the source by the same amount (rejection). Otherwise, nothing happens.
+type JagerAmblardRule struct{}
+
+func (r *JagerAmblardRule) Update(target, source *AgentBase, cb *ConfigBase) {
+	dist := BeliefDistance(target, source)
+	sign := float32(0)
+	switch {
+	case dist < cb.ConfidenceBound:
+		sign = 1
+	case dist > cb.RejectionBound:
+		sign = -1
+	default:
+		return
+	}
+	for i, bs := range source.Beliefs {
+		target.Beliefs[i] += sign * cb.InteractionEffect * (bs - target.Beliefs[i])
+	}
+}
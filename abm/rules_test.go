@@ -0,0 +1,62 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package abm
+
+import (
+	"testing"
+
+	"cogentcore.org/core/math32"
+)
+
+// ruleConfig is the configuration used for the rule tests.
+var ruleConfig = &ConfigBase{InteractionEffect: 0.5, ConfidenceBound: 0.3, RejectionBound: 0.6}
+
+// checkUpdate fails the test if updating a target agent with the given
+// beliefs from a source agent with the given beliefs with the given rule
+// does not result in the given beliefs.
+func checkUpdate(t *testing.T, rule Rules, target, source, want []float32) {
+	t.Helper()
+	ta, sa := &AgentBase{Beliefs: target}, &AgentBase{Beliefs: source}
+	rule.Rule().Update(ta, sa, ruleConfig)
+	for i := range want {
+		if math32.Abs(ta.Beliefs[i]-want[i]) > 1e-6 {
+			t.Errorf("%v: got %v from %v and %v, want %v", rule, ta.Beliefs, target, source, want)
+			return
+		}
+	}
+}
+
+func TestDeffuantWeisbuchRule(t *testing.T) {
+	// inside the confidence bound, the target moves by InteractionEffect
+	checkUpdate(t, RuleDeffuantWeisbuch, []float32{0.4}, []float32{0.6}, []float32{0.5})
+	// outside of it, nothing happens
+	checkUpdate(t, RuleDeffuantWeisbuch, []float32{0.1}, []float32{0.6}, []float32{0.1})
+}
+
+func TestHegselmannKrauseRule(t *testing.T) {
+	checkUpdate(t, RuleHegselmannKrause, []float32{0.5}, []float32{0.7}, []float32{0.6})
+	checkUpdate(t, RuleHegselmannKrause, []float32{0.1}, []float32{0.7}, []float32{0.1})
+
+	// the target moves to the mean of itself and the sources within the bound
+	target := &AgentBase{Beliefs: []float32{0.5}}
+	sources := []*AgentBase{{Beliefs: []float32{0.6}}, {Beliefs: []float32{0.7}}, {Beliefs: []float32{0.95}}}
+	(&HegselmannKrauseRule{}).UpdateGroup(target, sources, ruleConfig)
+	if got := target.Beliefs[0]; math32.Abs(got-0.6) > 1e-6 {
+		t.Errorf("got %g from the group, want 0.6", got)
+	}
+}
+
+func TestVoterRule(t *testing.T) {
+	checkUpdate(t, RuleVoter, []float32{0.1, 0.9}, []float32{0.8, 0.3}, []float32{0.8, 0.3})
+}
+
+func TestJagerAmblardRule(t *testing.T) {
+	// assimilation within the confidence bound
+	checkUpdate(t, RuleJagerAmblard, []float32{0.4}, []float32{0.6}, []float32{0.5})
+	// rejection beyond the rejection bound
+	checkUpdate(t, RuleJagerAmblard, []float32{0.2}, []float32{0.9}, []float32{-0.15})
+	// no change in the neutral zone between the bounds
+	checkUpdate(t, RuleJagerAmblard, []float32{0.2}, []float32{0.65}, []float32{0.2})
+}
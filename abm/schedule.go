@@ -0,0 +1,140 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package abm
+
+import (
+	"slices"
+
+	"cogentcore.org/core/math32"
+)
+
+// Schedules are the different schedules for updating agent beliefs
+// in each step of a simulation. In each step, every agent in the order
+// influences its partners through [Agent.Interact], except with a
+// [GroupInteractionRule], for which every agent in the order is instead
+// influenced by its partners through [Agent.InteractGroup].
+type Schedules int32 //enums:enum -trim-prefix Schedule
+
+const (
+
+	// ScheduleAsynchronous updates beliefs in place, in the order of
+	// [SimBase.Agents], so agents later in the order see beliefs that
+	// were already changed in the same step.
+	ScheduleAsynchronous Schedules = iota
+
+	// ScheduleSynchronous reads beliefs from a snapshot taken at the start
+	// of each step and writes the changes to a buffer that is applied at
+	// the end of the step, so the order of the agents has no effect.
+	ScheduleSynchronous
+
+	// ScheduleRandomSequential updates beliefs in place like
+	// [ScheduleAsynchronous], but in a random order of the agents
+	// that is shuffled in each step.
+	ScheduleRandomSequential
+)
+
+// startSchedule prepares the order of the agents for a step
+// according to [ConfigBase.Schedule].
+func (sb *SimBase) startSchedule() {
+	n := len(sb.Agents)
+	sb.order = slices.Grow(sb.order[:0], n)[:n]
+	for i := range sb.order {
+		sb.order[i] = i
+	}
+	if sb.Config.Base().Schedule == ScheduleRandomSequential {
+		sb.Rand.Shuffle(n, func(i, j int) {
+			sb.order[i], sb.order[j] = sb.order[j], sb.order[i]
+		})
+	}
+}
+
+// takeSnapshot takes a snapshot of the current beliefs of all agents
+// and resets the buffer of belief changes for [ScheduleSynchronous].
+func (sb *SimBase) takeSnapshot() {
+	if sb.Config.Base().Schedule != ScheduleSynchronous {
+		return
+	}
+	n := len(sb.Agents)
+	sb.snapshot = slices.Grow(sb.snapshot[:0], n)[:n]
+	sb.buffer = slices.Grow(sb.buffer[:0], n)[:n]
+	for i, a := range sb.Agents {
+		sb.snapshot[i] = append(sb.snapshot[i][:0], a.Base().Beliefs...)
+		sb.buffer[i] = append(sb.buffer[i][:0], a.Base().Beliefs...)
+	}
+}
+
+// bufferBeliefs adds the changes in the beliefs of the agent at the
+// given index relative to the snapshot to the buffer, and then restores
+// its beliefs to the snapshot, for [ScheduleSynchronous].
+func (sb *SimBase) bufferBeliefs(i int) {
+	beliefs := sb.Agents[i].Base().Beliefs
+	for k, b := range beliefs {
+		sb.buffer[i][k] += b - sb.snapshot[i][k]
+	}
+	copy(beliefs, sb.snapshot[i])
+}
+
+// endSchedule applies the buffered belief changes for [ScheduleSynchronous].
+func (sb *SimBase) endSchedule() {
+	if sb.Config.Base().Schedule != ScheduleSynchronous {
+		return
+	}
+	for i, a := range sb.Agents {
+		for k, b := range sb.buffer[i] {
+			a.Base().Beliefs[k] = math32.Clamp(b, 0, 1)
+		}
+	}
+}
+
+// applyValues has the agent at the given index apply its values
+// according to [ConfigBase.Schedule].
+func (sb *SimBase) applyValues(i int) {
+	sb.Agents[i].ApplyValues()
+	if sb.Config.Base().Schedule == ScheduleSynchronous {
+		sb.bufferBeliefs(i)
+	}
+}
+
+// interact has the agent at index i interact with the agent at
+// index j according to [ConfigBase.Schedule].
+func (sb *SimBase) interact(i, j int) {
+	a, other := sb.Agents[i], sb.Agents[j]
+	before := sb.startInteraction(a, other)
+	a.Interact(other)
+	sb.endInteraction(before, a, other)
+	if sb.Config.Base().Schedule == ScheduleSynchronous {
+		sb.bufferBeliefs(i)
+		sb.bufferBeliefs(j)
+	}
+}
+
+// interactAll has the agent at index i interact with the agents at the given
+// indices according to [ConfigBase.Schedule]. If [SimBase.Rule] is a
+// [GroupInteractionRule], the agent at index i is updated based on all of
+// the other agents at once through [Agent.InteractGroup]. Otherwise, each
+// of the other agents is updated based on the agent at index i through
+// [Agent.Interact]. See [GroupInteractionRule] for more about this asymmetry.
+func (sb *SimBase) interactAll(i int, js []int) {
+	if _, ok := sb.Rule.(GroupInteractionRule); !ok {
+		for _, j := range js {
+			sb.interact(i, j)
+		}
+		return
+	}
+	if len(js) == 0 {
+		return
+	}
+	a := sb.Agents[i]
+	others := make([]Agent, len(js))
+	for k, j := range js {
+		others[k] = sb.Agents[j]
+	}
+	before := sb.startInteraction(append([]Agent{a}, others...)...)
+	a.InteractGroup(others)
+	sb.endInteraction(before, a, others...)
+	if sb.Config.Base().Schedule == ScheduleSynchronous {
+		sb.bufferBeliefs(i)
+	}
+}
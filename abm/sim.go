@@ -4,14 +4,18 @@
 
 package abm
 
+import "cogentcore.org/core/base/errors"
+
 // Sim is the interface that all simulations implement.
 type Sim interface {
 
 	// Base returns the simulation as a [SimBase].
 	Base() *SimBase
 
-	// Init initializes the simulation with default values.
-	Init()
+	// Init initializes the simulation with default values. If the
+	// configuration is not valid according to [Config.Validate], it
+	// returns the error without initializing the simulation.
+	Init() error
 
 	// Step advances the simulation by one time step.
 	Step()
@@ -21,12 +25,25 @@ type Sim interface {
 // *S must implement the [Sim] interface.
 //
 // It also sets [SimBase.Config] to a [NewConfig] of type C.
-// *C must implement the [Config] interface.
+// *C must implement the [Config] interface. It panics if the simulation
+// cannot be initialized, which means that the default configuration is
+// not valid; use [NewSimWithConfig] to handle such errors.
 func NewSim[S, C any]() *S {
+	return errors.Must1(NewSimWithConfig[S](any(NewConfig[C]()).(Config)))
+}
+
+// NewSimWithConfig creates and initializes a new simulation of type S
+// with the given configuration, which must be of the configuration
+// type expected by the simulation. *S must implement the [Sim] interface.
+// If the simulation cannot be initialized, such as because the configuration
+// is not valid according to [Config.Validate], it returns nil and the error.
+func NewSimWithConfig[S any](cfg Config) (*S, error) {
 	simS := new(S)
 	sim := any(simS).(Sim)
 	sim.Base().This = sim
-	sim.Base().Config = any(NewConfig[C]()).(Config)
-	sim.Init()
-	return simS
+	sim.Base().Config = cfg
+	if err := sim.Init(); err != nil {
+		return nil, err
+	}
+	return simS, nil
 }
@@ -0,0 +1,118 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package abm
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// testSim is a minimal simulation for tests.
+type testSim struct {
+	SimBase
+}
+
+func (s *testSim) Init() error {
+	if err := s.Config.Validate(); err != nil {
+		return err
+	}
+	s.Agents = make([]Agent, s.Config.(*testConfig).Population)
+	for i := range s.Agents {
+		s.Agents[i] = &AgentBase{}
+	}
+	return s.SimBase.Init()
+}
+
+// testConfig is the configuration for a [testSim].
+type testConfig struct {
+	Population int
+	ConfigBase
+}
+
+func (c *testConfig) Validate() error {
+	if c.Population < 1 {
+		return errors.New("Population must be at least 1")
+	}
+	return c.ConfigBase.Validate()
+}
+
+// newTestSim returns a new initialized [testSim] with the given population
+// and the default configuration modified by the given function, if non-nil.
+func newTestSim(t *testing.T, population int, configure func(cb *ConfigBase)) *testSim {
+	t.Helper()
+	cfg := NewConfig[testConfig]()
+	cfg.Population = population
+	if configure != nil {
+		configure(&cfg.ConfigBase)
+	}
+	s, err := NewSimWithConfig[testSim](cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// agentStates returns the state of every agent in the given simulation.
+func agentStates(sim Sim) []AgentState {
+	cp, _ := sim.Base().Checkpoint()
+	return cp.Agents
+}
+
+// checkSameAgents fails the test if the agents of the given
+// simulations do not have exactly the same state.
+func checkSameAgents(t *testing.T, got, want Sim) {
+	t.Helper()
+	ga, wa := agentStates(got), agentStates(want)
+	if len(ga) != len(wa) {
+		t.Fatalf("got %d agents, want %d", len(ga), len(wa))
+	}
+	for i := range ga {
+		g, w := &ga[i], &wa[i]
+		if g.ID != w.ID || g.Position != w.Position || g.Velocity != w.Velocity ||
+			g.Influence != w.Influence || !slices.Equal(g.Beliefs, w.Beliefs) || !slices.Equal(g.Values, w.Values) {
+			t.Fatalf("agent %d differs:\ngot  %+v\nwant %+v", i, *g, *w)
+		}
+	}
+}
+
+func TestInitInvalid(t *testing.T) {
+	cfg := NewConfig[testConfig]()
+	cfg.Population = 10
+	cfg.Beliefs = 0
+	if s, err := NewSimWithConfig[testSim](cfg); s != nil || err == nil {
+		t.Fatalf("got %v, %v for an invalid config; want nil and an error", s, err)
+	}
+
+	s := newTestSim(t, 10, nil)
+	s.Step()
+	s.Config.Base().Beliefs = 0
+	if err := s.Init(); err == nil {
+		t.Fatal("got no error from Init with an invalid config")
+	}
+	if s.Steps != 1 || len(s.Agents) != 10 {
+		t.Fatalf("Init with an invalid config changed the simulation")
+	}
+
+	cb := &ConfigBase{Beliefs: 2, Distribution: DistributionMultivariate, BeliefCovariance: [][]float32{{1, 2}, {2, 1}}}
+	if _, err := cb.InitialBeliefs(s.Rand); err == nil {
+		t.Fatal("got no error from InitialBeliefs with a covariance matrix that is not positive definite")
+	}
+}
+
+func TestValidateRejectionBound(t *testing.T) {
+	cb := NewConfig[ConfigBase]()
+	cb.ConfidenceBound = 0.7
+	for _, rule := range []Rules{RuleDeffuantWeisbuch, RuleHegselmannKrause} {
+		cb.Rule = rule
+		if err := cb.Validate(); err != nil {
+			t.Errorf("%v: got %v for a confidence bound above the rejection bound", rule, err)
+		}
+	}
+	cb.Rule = RuleJagerAmblard
+	if err := cb.Validate(); err == nil {
+		t.Error("JagerAmblard: got no error for a confidence bound above the rejection bound")
+	}
+}
@@ -26,8 +26,52 @@ type SimBase struct {
 	// Steps are the number of time steps that have been executed.
 	Steps int
 
+	// Rule is the rule that determines how beliefs change in interactions.
+	// It is set from [ConfigBase.Rule] in [SimBase.Init].
+	Rule InteractionRule
+
+	// Rand is the random number generator for the simulation, seeded
+	// from [ConfigBase.Seed] in [SimBase.Init]. All stochastic decisions
+	// in the simulation should use it so that runs are reproducible.
+	Rand *rand.Rand
+
+	// source is the source of [SimBase.Rand].
+	source *rand.PCG
+
+	// beliefs is the distribution of the initial beliefs of agents,
+	// which is set from the configuration in [SimBase.Init].
+	beliefs *BeliefDistribution
+
+	// grid is the spatial index used to find interaction candidates.
+	grid Grid
+
+	// neighbors is a reusable buffer for interaction candidates.
+	neighbors []int
+
+	// order is the order in which agents are updated in the current step.
+	order []int
+
+	// snapshot contains the beliefs of each agent at the start of the
+	// current step for [ScheduleSynchronous].
+	snapshot [][]float32
+
+	// buffer contains the updated beliefs of each agent in the current
+	// step for [ScheduleSynchronous].
+	buffer [][]float32
+
 	// idCounter is used to generate unique IDs for agents.
 	idCounter uint64
+
+	// indexByID maps agent IDs to their index in [SimBase.Agents].
+	indexByID map[uint64]int
+
+	// hooks contains the registered event callbacks.
+	hooks hooks
+
+	// restoring is whether [SimBase.Restore] is initializing the simulation,
+	// in which case the [SimBase.OnInit] callbacks are called after the
+	// state of the checkpoint has been applied instead of by [SimBase.Init].
+	restoring bool
 }
 
 func (sb *SimBase) Base() *SimBase {
@@ -35,48 +79,122 @@ func (sb *SimBase) Base() *SimBase {
 }
 
 // Init initializes the simulation by initializing all agents
-// and connecting them according to their positions and beliefs.
-func (sb *SimBase) Init() {
+// and connecting them according to [ConfigBase.Network]. If [SimBase.Config]
+// is not valid, it returns the error without initializing anything.
+// Simulations that use their configuration before calling it, such as
+// to create their agents, should validate it first themselves.
+func (sb *SimBase) Init() error {
+	if err := sb.Config.Validate(); err != nil {
+		return err
+	}
+	beliefs, err := sb.Config.Base().BeliefDistribution()
+	if err != nil {
+		return err
+	}
+	sb.beliefs = beliefs
 	sb.Steps = 0
+	sb.idCounter = 0
+
+	seed := sb.Config.Base().Seed
+	sb.source = rand.NewPCG(seed, seed)
+	sb.Rand = rand.New(sb.source)
+	sb.Rule = sb.Config.Base().Rule.Rule()
 
 	for _, a := range sb.Agents {
 		a.Init(sb.This)
 	}
+	sb.UpdateIndex()
+	sb.InitNetwork()
+	if !sb.restoring {
+		sb.sendSim(&sb.hooks.init)
+	}
+	return nil
+}
+
+// UpdateIndex updates the index used by [SimBase.AgentByID].
+// It must be called whenever [SimBase.Agents] is changed.
+func (sb *SimBase) UpdateIndex() {
+	sb.indexByID = make(map[uint64]int, len(sb.Agents))
+	for i, a := range sb.Agents {
+		sb.indexByID[a.Base().ID] = i
+	}
+}
+
+// AgentByID returns the agent with the given ID and its index
+// in [SimBase.Agents], or nil and -1 if there is no such agent.
+func (sb *SimBase) AgentByID(id uint64) (Agent, int) {
+	i, ok := sb.indexByID[id]
+	if !ok {
+		return nil, -1
+	}
+	return sb.Agents[i], i
 }
 
 // Step advances the simulation by one time step.
 // It does this by having each agent interact with one or more randomly
-// selected agents as determined by the configuration parameters.
+// selected agents as determined by the configuration parameters,
+// with the partners chosen according to [ConfigBase.Interaction].
+// Agents are updated according to [ConfigBase.Schedule].
+// The callbacks registered with [SimBase.OnStepStart] and
+// [SimBase.OnStepEnd] are called before and after the step.
 func (sb *SimBase) Step() {
+	sb.sendSim(&sb.hooks.stepStart)
 	sb.Steps++
+	sb.startSchedule()
+	if sb.Config.Base().Parallel {
+		sb.stepParallel()
+	} else {
+		sb.stepSequential()
+	}
+	sb.endSchedule()
+	sb.sendSim(&sb.hooks.stepEnd)
+}
+
+// stepSequential advances the simulation by one time step
+// on the current goroutine, with each agent moving and then
+// interacting in the order of [SimBase.order].
+func (sb *SimBase) stepSequential() {
 	cb := sb.Config.Base()
+	sb.takeSnapshot()
 	ir := cb.InteractionRadius / float32(len(sb.Agents))
-	for i, a := range sb.Agents {
-		a.Base().StepPosition()
-		a.Base().ApplyValues()
-		for j, other := range sb.Agents {
-			if i == j {
-				continue
-			}
-			dist := a.Base().Position.DistanceToSquared(other.Base().Position)
-			if dist > ir {
-				continue
-			}
-
-			if cb.BeliefFilter > 0 {
-				beliefDist := float32(0)
-				for i, ba := range a.Base().Beliefs {
-					delta := other.Base().Beliefs[i] - ba
-					beliefDist += delta * delta
-				}
-				beliefDist = math32.Sqrt(beliefDist / float32(cb.Beliefs))
-				chanceInteract := (1 - beliefDist) / cb.BeliefFilter
-				if rand.Float32() > chanceInteract {
-					continue
-				}
-			}
-
-			a.Base().Interact(other)
+	sb.grid.Boundary = cb.Boundary
+	sb.grid.Build(sb.Agents, math32.Sqrt(ir))
+	for _, i := range sb.order {
+		a := sb.Agents[i]
+		a.StepPosition(sb.Rand)
+		sb.grid.Move(i, a.Base().Position)
+		sb.applyValues(i)
+		sb.neighbors = sb.partners(i, ir, sb.Rand, sb.neighbors[:0])
+		sb.neighbors = sb.filterPartners(i, sb.neighbors, sb.Rand)
+		sb.interactAll(i, sb.neighbors)
+	}
+}
+
+// filterPartners removes the partners of the agent at index i that do not
+// pass [SimBase.filterBeliefs] from the given partner indices in place,
+// and returns the result.
+func (sb *SimBase) filterPartners(i int, partners []int, rnd *rand.Rand) []int {
+	a := sb.Agents[i]
+	n := 0
+	for _, j := range partners {
+		if !sb.filterBeliefs(a, sb.Agents[j], rnd) {
+			continue
 		}
+		partners[n] = j
+		n++
+	}
+	return partners[:n]
+}
+
+// filterBeliefs returns whether the given agents should interact based on
+// the distance between their beliefs and [ConfigBase.BeliefFilter], using
+// the given random number generator.
+func (sb *SimBase) filterBeliefs(a, other Agent, rnd *rand.Rand) bool {
+	cb := sb.Config.Base()
+	if cb.BeliefFilter <= 0 {
+		return true
 	}
+	beliefDist := BeliefDistance(a.Base(), other.Base())
+	chanceInteract := (1 - beliefDist) / cb.BeliefFilter
+	return rnd.Float32() <= chanceInteract
 }
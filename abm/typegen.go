@@ -6,4 +6,4 @@ import (
 	"cogentcore.org/core/types"
 )
 
-var _ = types.AddType(&types.Type{Name: "github.com/kleroterio/abm/abm.ConfigBase", IDName: "config-base", Doc: "ConfigBase is the base type for configuration parameter sets.", Directives: []types.Directive{{Tool: "types", Directive: "add"}}, Fields: []types.Field{{Name: "Beliefs", Doc: "Beliefs is the number of political belief axes in the simulation."}, {Name: "PartisanPosition", Doc: "PartisanPosition determines whether agents are initialized with a\nspatial position corresponding to their beliefs, as in the seating of\nan elected legislature (only applicable for Beliefs >= 2)."}, {Name: "RandomInfluence", Doc: "RandomInfluence is the proportion of initial influence that is randomly\ndetermined as opposed to constant."}, {Name: "ChangeVelocity", Doc: "ChangeVelocity is the chance that an agent will change its spatial velocity."}, {Name: "BeliefVelocity", Doc: "BeliefVelocity is the proportion of an agent's velocity that is determined\nby the difference between its beliefs and current position. The rest is\ndetermined randomly (this is only applicable for Beliefs >= 2)."}, {Name: "VelocityMultiplier", Doc: "VelocityMultiplier is an overall multiplier on the velocity at which\nagents move."}, {Name: "InteractionRadius", Doc: "InteractionRadius is the multiplier on the maximum squared distance between\nagents for an interaction to occur, with the base value being 1/n\n(n = total number of agents)."}, {Name: "BeliefFilter", Doc: "BeliefFilter is the impact that normalized belief distance has on the chance of\ninteraction. For example, a value of 1 means that if agents have a normalized\nbelief distance of 0.7, the chance of interaction is 30%. A value of 2 would\nmake that chance 15%. A value of 0 disables belief filtering."}, {Name: "ExtremeBias", Doc: "ExtremeBias is the bias that agents have toward extreme beliefs.\n(i.e., beliefs closer to 0 or 1 have a greater influence in interactions\nthan those closer to 0.5)."}, {Name: "InteractionEffect", Doc: "InteractionEffect is how much an interaction impacts beliefs as a\nproportion of the initial difference in beliefs."}, {Name: "ValueEffect", Doc: "ValueEffect is how much an agent's immutable values impact their beliefs\nas a proportion of the difference between beliefs and values.\nValues have a kind of restorative force, pulling beliefs back to the original\nvalues over time."}}})
+var _ = types.AddType(&types.Type{Name: "github.com/kleroterio/abm/abm.ConfigBase", IDName: "config-base", Doc: "ConfigBase is the base type for configuration parameter sets.", Directives: []types.Directive{{Tool: "types", Directive: "add"}}, Fields: []types.Field{{Name: "Seed", Doc: "Seed is the seed for the random number generator of the simulation.\nThe same configuration and seed always result in the same simulation."}, {Name: "Parallel", Doc: "Parallel determines whether simulation steps are computed in parallel\nacross all available CPU cores. Parallel steps first move all agents\nand then compute all interactions, so they give different results than\nsequential steps, but the results are still fully determined by the\nconfiguration and seed, regardless of the number of cores."}, {Name: "Schedule", Doc: "Schedule is the schedule for updating agent beliefs in each step."}, {Name: "Beliefs", Doc: "Beliefs is the number of political belief axes in the simulation."}, {Name: "Distribution", Doc: "Distribution is the probability distribution that the\ninitial beliefs of agents are drawn from."}, {Name: "BeliefMean", Doc: "BeliefMean is the mean of the initial beliefs on each axis for\nthe Normal and Multivariate distributions, and the center\nbetween the two modes of the Bimodal distribution."}, {Name: "BeliefSD", Doc: "BeliefSD is the standard deviation of the initial beliefs on each axis for\nthe Normal and Multivariate distributions, and of each mode of the\nBimodal distribution (before truncation to the range from 0 to 1)."}, {Name: "BetaA", Doc: "BetaA is the first shape parameter (alpha) of the Beta distribution."}, {Name: "BetaB", Doc: "BetaB is the second shape parameter (beta) of the Beta distribution."}, {Name: "BimodalSeparation", Doc: "BimodalSeparation is the distance between the centers of the\ntwo modes of the Bimodal distribution."}, {Name: "BimodalWeight", Doc: "BimodalWeight is the proportion of agents in the upper\nmode of the Bimodal distribution."}, {Name: "BeliefCorrelation", Doc: "BeliefCorrelation is the correlation between the initial beliefs on\nevery pair of axes for the Multivariate distribution, which models\nideological constraint. It is only used if BeliefCovariance is not set."}, {Name: "BeliefCovariance", Doc: "BeliefCovariance is the full covariance matrix of the initial beliefs\nfor the Multivariate distribution, with one row and column per belief\naxis. If it is set, BeliefSD and BeliefCorrelation are not used\nfor the Multivariate distribution."}, {Name: "PartisanPosition", Doc: "PartisanPosition determines whether agents are initialized with a\nspatial position corresponding to their beliefs, as in the seating of\nan elected legislature (only applicable for Beliefs >= 2)."}, {Name: "RandomInfluence", Doc: "RandomInfluence is the proportion of initial influence that is randomly\ndetermined as opposed to constant."}, {Name: "ChangeVelocity", Doc: "ChangeVelocity is the chance that an agent will change its spatial velocity."}, {Name: "BeliefVelocity", Doc: "BeliefVelocity is the proportion of an agent's velocity that is determined\nby the difference between its beliefs and current position. The rest is\ndetermined randomly (this is only applicable for Beliefs >= 2)."}, {Name: "VelocityMultiplier", Doc: "VelocityMultiplier is an overall multiplier on the velocity at which\nagents move."}, {Name: "Interaction", Doc: "Interaction determines how agents choose their interaction partners:\nby spatial proximity, through the social network, or both."}, {Name: "Boundary", Doc: "Boundary is the boundary condition at the edges of the simulation space."}, {Name: "InteractionRadius", Doc: "InteractionRadius is the multiplier on the maximum squared distance between\nagents for an interaction to occur, with the base value being 1/n\n(n = total number of agents)."}, {Name: "BeliefFilter", Doc: "BeliefFilter is the impact that normalized belief distance has on the chance of\ninteraction. For example, a value of 1 means that if agents have a normalized\nbelief distance of 0.7, the chance of interaction is 30%. A value of 2 would\nmake that chance 15%. A value of 0 disables belief filtering."}, {Name: "Rule", Doc: "Rule is the rule that determines how beliefs change in interactions."}, {Name: "ExtremeBias", Doc: "ExtremeBias is the bias that agents have toward extreme beliefs.\n(i.e., beliefs closer to 0 or 1 have a greater influence in interactions\nthan those closer to 0.5)."}, {Name: "InteractionEffect", Doc: "InteractionEffect is how much an interaction impacts beliefs as a\nproportion of the initial difference in beliefs."}, {Name: "ConfidenceBound", Doc: "ConfidenceBound is the maximum normalized belief distance at which\nagents influence each other in the bounded confidence rules\n(Deffuant–Weisbuch and Hegselmann–Krause), and at which agents\nassimilate beliefs in the Jager–Amblard rule."}, {Name: "RejectionBound", Doc: "RejectionBound is the minimum normalized belief distance at which\nagents reject each other's beliefs in the Jager–Amblard rule."}, {Name: "ValueEffect", Doc: "ValueEffect is how much an agent's immutable values impact their beliefs\nas a proportion of the difference between beliefs and values.\nValues have a kind of restorative force, pulling beliefs back to the original\nvalues over time."}, {Name: "Network", Doc: "Network is the type of social network generated between agents."}, {Name: "NetworkDegree", Doc: "NetworkDegree is the target mean number of ties per agent in the\nsocial network. For Watts–Strogatz networks, it is rounded down to an\neven number, and for Barabási–Albert networks, each new agent forms\nhalf of this number of ties."}, {Name: "NetworkRewire", Doc: "NetworkRewire is the probability of rewiring each tie in a\nWatts–Strogatz network."}, {Name: "NetworkPartners", Doc: "NetworkPartners is the number of social network neighbors that each\nagent interacts with in each step (for Network and Mixed interaction)."}, {Name: "NegativeTies", Doc: "NegativeTies is the proportion of ties in the social network that are\noppositional (negative strength). The magnitude of tie strengths is random."}}})
@@ -0,0 +1,52 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package abm
+
+import (
+	"fmt"
+	"slices"
+
+	"cogentcore.org/core/enums"
+)
+
+// CheckRange returns an error if the given value of the configuration field
+// with the given name is not between lo and hi (inclusive), and nil otherwise.
+// It is intended for use in [Config.Validate].
+func CheckRange[T int | float32](field string, v, lo, hi T) error {
+	if v < lo || v > hi {
+		return fmt.Errorf("%s must be between %v and %v, but it is %v", field, lo, hi, v)
+	}
+	return nil
+}
+
+// CheckMin returns an error if the given value of the configuration field
+// with the given name is less than lo, and nil otherwise.
+// It is intended for use in [Config.Validate].
+func CheckMin[T int | float32](field string, v, lo T) error {
+	if v < lo {
+		return fmt.Errorf("%s must be at least %v, but it is %v", field, lo, v)
+	}
+	return nil
+}
+
+// CheckPositive returns an error if the given value of the configuration field
+// with the given name is not positive, and nil otherwise.
+// It is intended for use in [Config.Validate].
+func CheckPositive[T int | float32](field string, v T) error {
+	if v <= 0 {
+		return fmt.Errorf("%s must be positive, but it is %v", field, v)
+	}
+	return nil
+}
+
+// CheckEnum returns an error if the given value of the configuration field
+// with the given name is not a valid value of its enum type, and nil otherwise.
+// It is intended for use in [Config.Validate].
+func CheckEnum(field string, v enums.Enum) error {
+	if !slices.Contains(v.Values(), v) {
+		return fmt.Errorf("%s has an invalid value %d", field, v.Int64())
+	}
+	return nil
+}
@@ -122,7 +122,11 @@ func (ag *Agents) UpdateTable() {
 
 		beliefs := a.Base().Beliefs
 		ag.table.Column("Belief X").SetFloat(float64(beliefs[0]), i)
-		ag.table.Column("Belief Y").SetFloat(float64(beliefs[1]), i)
+		by := float32(0.5) // centered if there is only one belief axis
+		if len(beliefs) >= 2 {
+			by = beliefs[1]
+		}
+		ag.table.Column("Belief Y").SetFloat(float64(by), i)
 
 		ag.table.Column("Influence").SetFloat(float64(a.Base().Influence), i)
 	}
@@ -11,6 +11,7 @@ import (
 	"cogentcore.org/core/styles"
 	"cogentcore.org/core/tree"
 	"github.com/kleroterio/abm/abm"
+	"github.com/kleroterio/abm/stop"
 )
 
 // Sim2D implements a plot-based 2D representation of an agent-based model simulation.
@@ -20,6 +21,15 @@ type Sim2D struct {
 	// Sim is the simulation that this 2D representation is based on.
 	Sim abm.Sim
 
+	// Stop, if non-nil, is a condition that automatically stops the
+	// simulation when it is met while running.
+	Stop stop.Condition
+
+	// StatsInterval is the number of steps between computations of the
+	// statistics, which can be increased to speed up large simulations.
+	// If it is 0, the statistics are computed after every step.
+	StatsInterval int
+
 	// running is whether the simulation is currently running.
 	running bool
 
@@ -39,6 +49,11 @@ func (sw *Sim2D) Init() {
 
 	tree.AddChild(sw, func(w *core.Form) {
 		w.SetStruct(sw.Sim.Base().Config)
+		w.OnChange(func(e events.Event) {
+			if err := sw.Sim.Base().Config.Validate(); err != nil {
+				core.ErrorSnackbar(w, err, "Invalid configuration")
+			}
+		})
 	})
 	tree.AddChild(sw, func(w *core.Tabs) {
 		pop, _ := w.NewTab("Agents")
@@ -51,11 +66,12 @@ func (sw *Sim2D) Init() {
 
 // UpdatePlots updates the plots with the latest data from the simulation.
 // If step is true, it indicates that this update is after a simulation step,
-// which leads to the computation of statistics.
+// which leads to the computation of statistics if the step is a multiple
+// of [Sim2D.StatsInterval].
 func (sw *Sim2D) UpdatePlots(step bool) {
 	sw.population.UpdatePlot()
-	if step {
-		sw.stats.ComputeStats(sw.population.table)
+	if step && sw.Sim.Base().Steps%max(sw.StatsInterval, 1) == 0 {
+		sw.stats.ComputeStats()
 	}
 	if sw.stats.plot.IsVisible() {
 		sw.stats.plot.UpdatePlot()
@@ -67,7 +83,10 @@ func (sw *Sim2D) MakeToolbar(p *tree.Plan) {
 		w.SetText("Reset").SetIcon(icons.Update)
 		w.OnClick(func(e events.Event) {
 			sw.running = false
-			sw.Sim.Init()
+			if err := sw.Sim.Init(); err != nil {
+				core.ErrorSnackbar(w, err, "Invalid configuration")
+				return
+			}
 			sw.UpdatePlots(false)
 			core.AsWidget(w.Parent).Restyle()
 		})
@@ -77,6 +96,10 @@ func (sw *Sim2D) MakeToolbar(p *tree.Plan) {
 		w.OnClick(func(e events.Event) {
 			sw.running = true
 			core.AsWidget(w.Parent).Restyle()
+			start := sw.Sim.Base().Steps
+			if sw.Stop != nil {
+				sw.Stop.Start(sw.Sim)
+			}
 			sw.Animate(func(a *core.Animation) {
 				if !sw.running {
 					a.Done = true
@@ -84,6 +107,12 @@ func (sw *Sim2D) MakeToolbar(p *tree.Plan) {
 				}
 				sw.Sim.Step()
 				sw.UpdatePlots(true)
+				if sw.Stop != nil && sw.Stop.Check(sw.Sim) {
+					sw.running = false
+					res := stop.NewResult(sw.Stop, sw.Sim.Base().Steps-start)
+					core.MessageSnackbar(w, "Simulation "+res.String())
+					core.AsWidget(w.Parent).Restyle()
+				}
 			})
 		})
 	})
@@ -5,17 +5,15 @@
 package abmcore
 
 import (
-	"math"
-
 	"cogentcore.org/core/core"
 	"cogentcore.org/core/styles"
 	"cogentcore.org/core/tree"
 	"cogentcore.org/lab/plot"
 	"cogentcore.org/lab/plotcore"
-	"cogentcore.org/lab/stats/stats"
 	"cogentcore.org/lab/table"
 	"cogentcore.org/lab/tensor"
 	"github.com/kleroterio/abm/abm"
+	"github.com/kleroterio/abm/metrics"
 )
 
 // Stats is a customizable plot of statistics from a simulation.
@@ -49,7 +47,13 @@ func (st *Stats) Init() {
 
 func (st *Stats) makeTable() {
 	st.table = table.New()
-	st.table.AddColumn("Polarization", tensor.NewFloat32(1))
+	st.table.AddIntColumn("Step")
+	plot.Styler(st.table.Column("Step"), func(s *plot.Style) {
+		s.Role = plot.X
+	})
+	for _, name := range []string{"Polarization", "Esteban-Ray", "Largest Cluster", "Value Distance", "Influence Gini"} {
+		st.table.AddColumn(name, tensor.NewFloat32(1))
+	}
 
 	plot.Styler(st.table.Column("Polarization"), func(s *plot.Style) {
 		s.On = true
@@ -59,20 +63,34 @@ func (st *Stats) makeTable() {
 	st.plot.SetTable(st.table)
 }
 
-// ComputeStats computes the statistics from the given agent data.
-func (st *Stats) ComputeStats(agentTable *table.Table) {
+// ComputeStats computes the statistics from the current state of the simulation
+// using the functions in package [metrics], and adds them to the table as
+// the row for the current step, removing any rows for later steps
+// (which happens after the simulation is reset).
+func (st *Stats) ComputeStats() {
 	if st.table == nil {
 		st.makeTable()
 	}
 
 	steps := st.Sim.Base().Steps
+	agents := st.Sim.Base().Agents
 
-	st.table.SetNumRows(steps)
+	row := st.table.NumRows()
+	for row > 0 && st.table.Column("Step").IntRow(row-1, 0) >= steps {
+		row--
+	}
+	st.table.SetNumRows(row + 1)
 
-	varX := stats.VarPop(agentTable.Column("Belief X")).Float(0)
-	varY := stats.VarPop(agentTable.Column("Belief Y")).Float(0)
-	stddev := math.Sqrt(varX + varY)
-	st.table.Column("Polarization").SetFloat(stddev, steps-1)
+	largest := 0.0
+	if clusters := metrics.Clusters(agents, metrics.DefaultClusterThreshold); len(clusters) > 0 {
+		largest = float64(len(clusters[0])) / float64(len(agents))
+	}
+	st.table.Column("Step").SetInt(steps, row)
+	st.table.Column("Polarization").SetFloat(metrics.Polarization(agents), row)
+	st.table.Column("Esteban-Ray").SetFloat(metrics.EstebanRay(agents, metrics.DefaultAlpha, metrics.DefaultBins), row)
+	st.table.Column("Largest Cluster").SetFloat(largest, row)
+	st.table.Column("Value Distance").SetFloat(metrics.ValueDistance(agents), row)
+	st.table.Column("Influence Gini").SetFloat(metrics.InfluenceGini(agents), row)
 }
 
 func (st *Stats) MakeToolbar(p *tree.Plan) {
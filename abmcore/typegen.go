@@ -6,6 +6,7 @@ import (
 	"cogentcore.org/core/tree"
 	"cogentcore.org/core/types"
 	"github.com/kleroterio/abm/abm"
+	"github.com/kleroterio/abm/stop"
 )
 
 var _ = types.AddType(&types.Type{Name: "github.com/kleroterio/abm/abmcore.Agents", IDName: "agents", Doc: "Agents is a customizable 2D plot of the agents in a simulation.", Embeds: []types.Field{{Name: "Frame"}}, Fields: []types.Field{{Name: "Sim", Doc: "Sim is the simulation that this 2D representation is based on."}, {Name: "Mode", Doc: "Mode is the current preset plotting mode."}, {Name: "table", Doc: "table is the data table for plotting."}, {Name: "plot", Doc: "plot is the plot editor widget."}}})
@@ -22,7 +23,7 @@ func (t *Agents) SetSim(v abm.Sim) *Agents { t.Sim = v; return t }
 // Mode is the current preset plotting mode.
 func (t *Agents) SetMode(v Modes) *Agents { t.Mode = v; return t }
 
-var _ = types.AddType(&types.Type{Name: "github.com/kleroterio/abm/abmcore.Sim2D", IDName: "sim2-d", Doc: "Sim2D implements a plot-based 2D representation of an agent-based model simulation.", Embeds: []types.Field{{Name: "Splits"}}, Fields: []types.Field{{Name: "Sim", Doc: "Sim is the simulation that this 2D representation is based on."}, {Name: "running", Doc: "running is whether the simulation is currently running."}, {Name: "population", Doc: "population is the plot of the agent population."}, {Name: "stats", Doc: "stats is the plot of the simulation statistics."}}})
+var _ = types.AddType(&types.Type{Name: "github.com/kleroterio/abm/abmcore.Sim2D", IDName: "sim2-d", Doc: "Sim2D implements a plot-based 2D representation of an agent-based model simulation.", Embeds: []types.Field{{Name: "Splits"}}, Fields: []types.Field{{Name: "Sim", Doc: "Sim is the simulation that this 2D representation is based on."}, {Name: "Stop", Doc: "Stop, if non-nil, is a condition that automatically stops the\nsimulation when it is met while running."}, {Name: "StatsInterval", Doc: "StatsInterval is the number of steps between computations of the\nstatistics, which can be increased to speed up large simulations.\nIf it is 0, the statistics are computed after every step."}, {Name: "running", Doc: "running is whether the simulation is currently running."}, {Name: "population", Doc: "population is the plot of the agent population."}, {Name: "stats", Doc: "stats is the plot of the simulation statistics."}}})
 
 // NewSim2D returns a new [Sim2D] with the given optional parent:
 // Sim2D implements a plot-based 2D representation of an agent-based model simulation.
@@ -32,6 +33,17 @@ func NewSim2D(parent ...tree.Node) *Sim2D { return tree.New[Sim2D](parent...) }
 // Sim is the simulation that this 2D representation is based on.
 func (t *Sim2D) SetSim(v abm.Sim) *Sim2D { t.Sim = v; return t }
 
+// SetStop sets the [Sim2D.Stop]:
+// Stop, if non-nil, is a condition that automatically stops the
+// simulation when it is met while running.
+func (t *Sim2D) SetStop(v stop.Condition) *Sim2D { t.Stop = v; return t }
+
+// SetStatsInterval sets the [Sim2D.StatsInterval]:
+// StatsInterval is the number of steps between computations of the
+// statistics, which can be increased to speed up large simulations.
+// If it is 0, the statistics are computed after every step.
+func (t *Sim2D) SetStatsInterval(v int) *Sim2D { t.StatsInterval = v; return t }
+
 var _ = types.AddType(&types.Type{Name: "github.com/kleroterio/abm/abmcore.Stats", IDName: "stats", Doc: "Stats is a customizable plot of statistics from a simulation.", Embeds: []types.Field{{Name: "Frame"}}, Fields: []types.Field{{Name: "Sim", Doc: "Sim is the simulation that this 2D representation is based on."}, {Name: "table", Doc: "table is the stats data table for plotting."}, {Name: "plot", Doc: "plot is the plot editor widget."}}})
 
 // NewStats returns a new [Stats] with the given optional parent:
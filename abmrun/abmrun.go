@@ -0,0 +1,9 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package abmrun provides headless running of agent-based modeling
+// simulations of political systems, with the results saved to files.
+package abmrun
+
+//go:generate core generate
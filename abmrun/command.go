@@ -0,0 +1,130 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package abmrun
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"cogentcore.org/core/base/reflectx"
+	"cogentcore.org/core/cli"
+	"github.com/kleroterio/abm/abm"
+	"github.com/kleroterio/abm/stop"
+)
+
+// Command is the configuration for a headless command-line run
+// of a simulation with a configuration of type C (see [Main]).
+type Command[C any] struct {
+
+	// ConfigFile is a TOML or JSON file to open the simulation
+	// configuration from, before applying any other flags.
+	ConfigFile string `flag:"config,cfg"`
+
+	// Steps is the maximum number of steps to run.
+	Steps int `default:"1000"`
+
+	// Tolerance, if positive, stops the run early once the mean absolute
+	// change in beliefs per step has been below it for [Command.Patience]
+	// consecutive steps (see [stop.Stable]).
+	Tolerance float32
+
+	// Patience is the number of consecutive stable steps
+	// required to stop the run early with [Command.Tolerance].
+	Patience int `default:"10"`
+
+	// StatsInterval is the number of steps between the recorded rows
+	// of statistics (see [Runner.Interval]), which can be increased
+	// to speed up runs with many agents.
+	StatsInterval int `default:"1"`
+
+	// PopulationFile is a CSV file to open the initial population of agents
+	// from, as in [abm.SimBase.ReadPopulation]. If the simulation configuration
+	// has a Population field, it is set to the number of agents in the file.
+	PopulationFile string `flag:"population-file"`
+
+	// Trajectories, if positive, is the sampling interval in steps for
+	// recording the trajectory of each agent with a [Recorder], which is
+	// saved to a file named trajectories in the output directory.
+	Trajectories int
+
+	// Out is the directory to save the results in.
+	Out string `default:"results"`
+
+	// Format is the format to save the results in.
+	Format Formats `default:"CSV"`
+
+	// SimConfig is the simulation configuration. Its fields can be set
+	// with flags of the same name, such as -BeliefFilter 0.2.
+	SimConfig C
+}
+
+// Main runs a simulation of type S with a configuration of type C
+// (as in [abm.NewSim]) without a GUI, as configured by the command-line
+// arguments in [os.Args] (see [Command]). It prints the [stop.Result] of the run and
+// saves the results in the output directory as in [Runner.Save], along with the
+// configuration that was actually used in config.toml and the trajectories if
+// [Command.Trajectories] is set. It is intended to be called from a main function.
+func Main[S, C any]() error {
+	cmd := &Command[C]{}
+	if err := cli.SetFromDefaults(cmd); err != nil {
+		return err
+	}
+	cfg := any(&cmd.SimConfig).(abm.Config)
+	cfg.Defaults()
+
+	// we set the arguments before and after opening the config file,
+	// so that we know the file and the other arguments take precedence
+	args := os.Args[1:]
+	if _, err := cli.SetFromArgs(cmd, args, cli.ErrNotFound); err != nil {
+		return err
+	}
+	if cmd.ConfigFile != "" {
+		if err := abm.OpenConfig(cfg, cmd.ConfigFile); err != nil {
+			return err
+		}
+		if _, err := cli.SetFromArgs(cmd, args, cli.ErrNotFound); err != nil {
+			return err
+		}
+	}
+
+	simS, err := abm.NewSimWithConfig[S](cfg)
+	if err != nil {
+		return err
+	}
+	sim := any(simS).(abm.Sim)
+	if cmd.PopulationFile != "" {
+		if err := sim.Base().OpenPopulation(cmd.PopulationFile); err != nil {
+			return err
+		}
+		// the saved configuration should match the population that was used
+		if _, err := reflectx.FieldByPath(reflect.ValueOf(cfg), "Population"); err == nil {
+			if err := SetParam(cfg, "Population", len(sim.Base().Agents)); err != nil {
+				return err
+			}
+		}
+	}
+	r := NewRunner(sim)
+	r.Interval = cmd.StatsInterval
+	var rec *Recorder
+	if cmd.Trajectories > 0 {
+		rec = NewRecorder(r.Sim, cmd.Trajectories)
+	}
+	var cond stop.Condition = stop.MaxSteps(cmd.Steps)
+	if cmd.Tolerance > 0 {
+		cond = stop.NewOr(stop.NewStable(cmd.Tolerance, cmd.Patience), cond)
+	}
+	fmt.Println(r.RunUntil(cond))
+	if err := r.Save(cmd.Out, cmd.Format); err != nil {
+		return err
+	}
+	if rec != nil {
+		if err := rec.Save(filepath.Join(cmd.Out, "trajectories"), cmd.Format); err != nil {
+			return err
+		}
+	}
+	return abm.SaveConfig(cfg, filepath.Join(cmd.Out, "config.toml"))
+}
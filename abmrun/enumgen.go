@@ -0,0 +1,91 @@
+// Code generated by "core generate"; DO NOT EDIT.
+
+package abmrun
+
+import (
+	"cogentcore.org/core/enums"
+)
+
+var _FormatsValues = []Formats{0, 1}
+
+// FormatsN is the highest valid value for type Formats, plus one.
+const FormatsN Formats = 2
+
+var _FormatsValueMap = map[string]Formats{`CSV`: 0, `JSON`: 1}
+
+var _FormatsDescMap = map[Formats]string{0: `FormatCSV saves results as CSV files with typed column headers, which can be reopened as [table.Table]s.`, 1: `FormatJSON saves results as JSON files, each containing an array with one object per row.`}
+
+var _FormatsMap = map[Formats]string{0: `CSV`, 1: `JSON`}
+
+// String returns the string representation of this Formats value.
+func (i Formats) String() string { return enums.String(i, _FormatsMap) }
+
+// SetString sets the Formats value from its string representation,
+// and returns an error if the string is invalid.
+func (i *Formats) SetString(s string) error {
+	return enums.SetString(i, s, _FormatsValueMap, "Formats")
+}
+
+// Int64 returns the Formats value as an int64.
+func (i Formats) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the Formats value from an int64.
+func (i *Formats) SetInt64(in int64) { *i = Formats(in) }
+
+// Desc returns the description of the Formats value.
+func (i Formats) Desc() string { return enums.Desc(i, _FormatsDescMap) }
+
+// FormatsValues returns all possible values for the type Formats.
+func FormatsValues() []Formats { return _FormatsValues }
+
+// Values returns all possible values for the type Formats.
+func (i Formats) Values() []enums.Enum { return enums.Values(_FormatsValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i Formats) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *Formats) UnmarshalText(text []byte) error { return enums.UnmarshalText(i, text, "Formats") }
+
+var _SamplingsValues = []Samplings{0, 1}
+
+// SamplingsN is the highest valid value for type Samplings, plus one.
+const SamplingsN Samplings = 2
+
+var _SamplingsValueMap = map[string]Samplings{`Grid`: 0, `LatinHypercube`: 1}
+
+var _SamplingsDescMap = map[Samplings]string{0: `SamplingGrid runs every combination of the values of all parameters.`, 1: `SamplingLatinHypercube runs [Sweep.Samples] points chosen by Latin hypercube sampling, in which the range of each parameter is divided into one stratum per point, and each stratum is sampled exactly once.`}
+
+var _SamplingsMap = map[Samplings]string{0: `Grid`, 1: `LatinHypercube`}
+
+// String returns the string representation of this Samplings value.
+func (i Samplings) String() string { return enums.String(i, _SamplingsMap) }
+
+// SetString sets the Samplings value from its string representation,
+// and returns an error if the string is invalid.
+func (i *Samplings) SetString(s string) error {
+	return enums.SetString(i, s, _SamplingsValueMap, "Samplings")
+}
+
+// Int64 returns the Samplings value as an int64.
+func (i Samplings) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the Samplings value from an int64.
+func (i *Samplings) SetInt64(in int64) { *i = Samplings(in) }
+
+// Desc returns the description of the Samplings value.
+func (i Samplings) Desc() string { return enums.Desc(i, _SamplingsDescMap) }
+
+// SamplingsValues returns all possible values for the type Samplings.
+func SamplingsValues() []Samplings { return _SamplingsValues }
+
+// Values returns all possible values for the type Samplings.
+func (i Samplings) Values() []enums.Enum { return enums.Values(_SamplingsValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i Samplings) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *Samplings) UnmarshalText(text []byte) error {
+	return enums.UnmarshalText(i, text, "Samplings")
+}
@@ -0,0 +1,218 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package abmrun
+
+import (
+	"fmt"
+	"slices"
+
+	"cogentcore.org/lab/table"
+	"github.com/kleroterio/abm/abm"
+)
+
+// Recorder records the time series of the state of each agent in a
+// simulation at a sampling interval. The state is stored in a compact
+// columnar layout, with one row per agent per sample, and can be queried
+// by agent ID and step range as a [table.Table].
+type Recorder struct {
+
+	// Sim is the simulation being recorded.
+	Sim abm.Sim
+
+	// Interval is the number of steps between samples.
+	Interval int
+
+	// Beliefs is the number of belief axes of the agents.
+	Beliefs int
+
+	// Steps contains the value of [abm.SimBase.Steps] for each sample,
+	// in ascending order.
+	Steps []int
+
+	// Starts contains the index of the first row of each sample.
+	Starts []int
+
+	// IDs contains the ID of the agent in each row.
+	IDs []uint64
+
+	// PositionsX contains the X position of the agent in each row.
+	PositionsX []float32
+
+	// PositionsY contains the Y position of the agent in each row.
+	PositionsY []float32
+
+	// Influences contains the influence of the agent in each row.
+	Influences []float32
+
+	// BeliefValues contains the beliefs of the agent in each row, with
+	// [Recorder.Beliefs] consecutive values per row.
+	BeliefValues []float32
+
+	// rowsByID contains the rows of each agent ID in ascending order.
+	rowsByID map[uint64][]int
+
+	// removeHooks unregisters the simulation event callbacks of the recorder.
+	removeHooks []func()
+}
+
+// NewRecorder returns a new [Recorder] for the given simulation that records
+// the current state of the simulation and then its state after every step
+// in which [abm.SimBase.Steps] is a multiple of the given interval. It is
+// cleared whenever the simulation is initialized again. Use [Recorder.Detach]
+// to stop recording.
+func NewRecorder(sim abm.Sim, interval int) *Recorder {
+	r := &Recorder{Sim: sim, Interval: max(interval, 1)}
+	r.Clear()
+	r.Record()
+	sb := sim.Base()
+	r.removeHooks = []func(){
+		sb.OnStepEnd(func(sim abm.Sim) {
+			if sim.Base().Steps%r.Interval == 0 {
+				r.Record()
+			}
+		}),
+		sb.OnInit(func(sim abm.Sim) {
+			r.Clear()
+			r.Record()
+		}),
+	}
+	return r
+}
+
+// Detach stops the recorder from automatically recording the simulation,
+// keeping the samples that have already been recorded.
+func (r *Recorder) Detach() {
+	for _, remove := range r.removeHooks {
+		remove()
+	}
+	r.removeHooks = nil
+}
+
+// Clear removes all of the recorded samples.
+func (r *Recorder) Clear() {
+	r.Beliefs = r.Sim.Base().Config.Base().Beliefs
+	r.Steps = r.Steps[:0]
+	r.Starts = r.Starts[:0]
+	r.IDs = r.IDs[:0]
+	r.PositionsX = r.PositionsX[:0]
+	r.PositionsY = r.PositionsY[:0]
+	r.Influences = r.Influences[:0]
+	r.BeliefValues = r.BeliefValues[:0]
+	r.rowsByID = map[uint64][]int{}
+}
+
+// Record records a sample of the current state of every agent.
+func (r *Recorder) Record() {
+	sb := r.Sim.Base()
+	r.Steps = append(r.Steps, sb.Steps)
+	r.Starts = append(r.Starts, len(r.IDs))
+	for _, a := range sb.Agents {
+		ab := a.Base()
+		r.rowsByID[ab.ID] = append(r.rowsByID[ab.ID], len(r.IDs))
+		r.IDs = append(r.IDs, ab.ID)
+		r.PositionsX = append(r.PositionsX, ab.Position.X)
+		r.PositionsY = append(r.PositionsY, ab.Position.Y)
+		r.Influences = append(r.Influences, ab.Influence)
+		r.BeliefValues = append(r.BeliefValues, ab.Beliefs[:r.Beliefs]...)
+	}
+}
+
+// NumRows returns the total number of recorded rows.
+func (r *Recorder) NumRows() int {
+	return len(r.IDs)
+}
+
+// sampleOf returns the index of the sample containing the given row.
+func (r *Recorder) sampleOf(row int) int {
+	i, _ := slices.BinarySearch(r.Starts, row+1)
+	return i - 1
+}
+
+// Rows returns the indexes of the rows for the agents with the given IDs
+// in samples with steps from start to end (inclusive), in ascending order.
+// If ids is nil, the rows for all agents are returned.
+func (r *Recorder) Rows(ids []uint64, start, end int) []int {
+	first, _ := slices.BinarySearch(r.Steps, start)
+	last, _ := slices.BinarySearch(r.Steps, end+1)
+	if first >= last {
+		return nil
+	}
+	lo := r.Starts[first]
+	hi := len(r.IDs)
+	if last < len(r.Starts) {
+		hi = r.Starts[last]
+	}
+	var rows []int
+	if ids == nil {
+		for row := lo; row < hi; row++ {
+			rows = append(rows, row)
+		}
+		return rows
+	}
+	for _, id := range ids {
+		for _, row := range r.rowsByID[id] {
+			if row >= lo && row < hi {
+				rows = append(rows, row)
+			}
+		}
+	}
+	slices.Sort(rows)
+	return rows
+}
+
+// Table returns a table with the recorded rows for the agents with the given
+// IDs in samples with steps from start to end (inclusive), as in [Recorder.Rows].
+// It has the columns Step, ID, Position X, Position Y, Influence, and Belief i
+// for each belief axis i.
+func (r *Recorder) Table(ids []uint64, start, end int) *table.Table {
+	rows := r.Rows(ids, start, end)
+	dt := table.New("Trajectories")
+	dt.AddIntColumn("Step")
+	dt.AddIntColumn("ID")
+	for _, name := range []string{"Position X", "Position Y", "Influence"} {
+		dt.AddFloat64Column(name)
+	}
+	for i := range r.Beliefs {
+		dt.AddFloat64Column(fmt.Sprintf("Belief %d", i))
+	}
+	dt.SetNumRows(len(rows))
+	for k, row := range rows {
+		dt.Column("Step").SetInt(r.Steps[r.sampleOf(row)], k)
+		dt.Column("ID").SetInt(int(r.IDs[row]), k)
+		dt.Column("Position X").SetFloat(float64(r.PositionsX[row]), k)
+		dt.Column("Position Y").SetFloat(float64(r.PositionsY[row]), k)
+		dt.Column("Influence").SetFloat(float64(r.Influences[row]), k)
+		for i := range r.Beliefs {
+			dt.Column(fmt.Sprintf("Belief %d", i)).SetFloat(float64(r.BeliefValues[row*r.Beliefs+i]), k)
+		}
+	}
+	return dt
+}
+
+// Agent returns a table with the trajectory of the agent with the given ID,
+// with one row per sample in which it was present, as in [Recorder.Table].
+func (r *Recorder) Agent(id uint64) *table.Table {
+	return r.Table([]uint64{id}, 0, r.lastStep())
+}
+
+// Range returns a table with the trajectories of all agents in samples with
+// steps from start to end (inclusive), as in [Recorder.Table].
+func (r *Recorder) Range(start, end int) *table.Table {
+	return r.Table(nil, start, end)
+}
+
+// Save saves the trajectories of all agents to the given file in the given
+// format, adding the extension of the format to the filename as in [SaveTable].
+func (r *Recorder) Save(filename string, format Formats) error {
+	return SaveTable(r.Range(0, r.lastStep()), filename, format)
+}
+
+// lastStep returns the step of the last sample, or 0 if there are none.
+func (r *Recorder) lastStep() int {
+	if len(r.Steps) == 0 {
+		return 0
+	}
+	return r.Steps[len(r.Steps)-1]
+}
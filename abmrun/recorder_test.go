@@ -0,0 +1,53 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package abmrun
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/kleroterio/abm/abm"
+	"github.com/kleroterio/abm/sims/basic"
+)
+
+func TestRecorderDetach(t *testing.T) {
+	sim := abm.NewSim[basic.Sim, basic.Config]()
+	r := NewRecorder(sim, 2)
+	for range 4 {
+		sim.Step()
+	}
+	r.Detach()
+	for range 4 {
+		sim.Step()
+	}
+	if want := []int{0, 2, 4}; !slices.Equal(r.Steps, want) {
+		t.Fatalf("got samples at steps %v, want %v", r.Steps, want)
+	}
+	if n := r.NumRows(); n != 3*len(sim.Agents) {
+		t.Fatalf("got %d rows, want %d", n, 3*len(sim.Agents))
+	}
+}
+
+func TestRecorderRestore(t *testing.T) {
+	sim := abm.NewSim[basic.Sim, basic.Config]()
+	for range 4 {
+		sim.Step()
+	}
+	cp, err := sim.Checkpoint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resumed := abm.NewSim[basic.Sim, basic.Config]()
+	r := NewRecorder(resumed, 2)
+	if err := resumed.Restore(cp); err != nil {
+		t.Fatal(err)
+	}
+	for range 4 {
+		resumed.Step()
+	}
+	if want := []int{4, 6, 8}; !slices.Equal(r.Steps, want) {
+		t.Fatalf("got samples at steps %v, want %v", r.Steps, want)
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package abmrun
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"cogentcore.org/core/base/fsx"
+	"cogentcore.org/lab/table"
+	"cogentcore.org/lab/tensor"
+	"github.com/kleroterio/abm/abm"
+	"github.com/kleroterio/abm/metrics"
+	"github.com/kleroterio/abm/stop"
+)
+
+// Formats are the different file formats for saving results.
+type Formats int32 //enums:enum -trim-prefix Format
+
+const (
+
+	// FormatCSV saves results as CSV files with typed column headers,
+	// which can be reopened as [table.Table]s.
+	FormatCSV Formats = iota
+
+	// FormatJSON saves results as JSON files, each containing
+	// an array with one object per row.
+	FormatJSON
+)
+
+// Runner runs a simulation without a GUI and records
+// statistics about the simulation after each step.
+type Runner struct {
+
+	// Sim is the simulation being run.
+	Sim abm.Sim
+
+	// Interval is the number of steps between the rows of statistics
+	// recorded by [Runner.Run] and [Runner.RunUntil], which record them after
+	// every step in which [abm.SimBase.Steps] is a multiple of it, and after
+	// the last step of the run. If it is 0, only the last step is recorded.
+	Interval int
+
+	// Stats is the table of statistics, with one row per recorded step
+	// (starting with the initial state at step 0 for [NewRunner]).
+	Stats *table.Table
+}
+
+// NewRunner returns a new [Runner] for the given initialized simulation
+// that records the statistics after every step, with the statistics
+// of its current state recorded.
+func NewRunner(sim abm.Sim) *Runner {
+	r := newRunner(sim)
+	r.Interval = 1
+	r.Record()
+	return r
+}
+
+// newRunner returns a new [Runner] for the given initialized simulation
+// with an empty [Runner.Stats] table.
+func newRunner(sim abm.Sim) *Runner {
+	r := &Runner{Sim: sim}
+	r.Stats = table.New("Stats")
+	r.Stats.AddIntColumn("Step")
+	for _, name := range []string{"Polarization", "Esteban-Ray", "Clusters", "Largest Cluster", "Value Distance", "Influence Gini"} {
+		r.Stats.AddFloat64Column(name)
+	}
+	for i := range sim.Base().Config.Base().Beliefs {
+		r.Stats.AddFloat64Column(fmt.Sprintf("Belief %d Mean", i))
+		r.Stats.AddFloat64Column(fmt.Sprintf("Belief %d Bimodality", i))
+	}
+	return r
+}
+
+// Run runs the given number of steps of the simulation,
+// recording the statistics as determined by [Runner.Interval].
+func (r *Runner) Run(steps int) {
+	for range steps {
+		r.step()
+	}
+	r.recordLast()
+}
+
+// RunUntil runs the simulation until the given condition is met,
+// recording the statistics as determined by [Runner.Interval],
+// and returns the result. The condition is checked before the first step
+// and after each step, so no steps are run if it is already met.
+// Use [stop.MaxSteps] (for example, in a [stop.Or]) to ensure that the run stops.
+func (r *Runner) RunUntil(cond stop.Condition) *stop.Result {
+	start := r.Sim.Base().Steps
+	cond.Start(r.Sim)
+	for !cond.Check(r.Sim) {
+		r.step()
+	}
+	r.recordLast()
+	return stop.NewResult(cond, r.Sim.Base().Steps-start)
+}
+
+// step runs one step of the simulation, recording the statistics
+// if [abm.SimBase.Steps] is a multiple of [Runner.Interval].
+func (r *Runner) step() {
+	r.Sim.Step()
+	if r.Interval > 0 && r.Sim.Base().Steps%r.Interval == 0 {
+		r.Record()
+	}
+}
+
+// recordLast records the statistics of the current step
+// if they are not already the last row of [Runner.Stats].
+func (r *Runner) recordLast() {
+	if n := r.Stats.NumRows(); n > 0 && r.Stats.Column("Step").IntRow(n-1, 0) == r.Sim.Base().Steps {
+		return
+	}
+	r.Record()
+}
+
+// Record adds a row to [Runner.Stats] with the statistics
+// of the current state of the simulation, as computed by
+// the functions in package [metrics] with their default parameters.
+func (r *Runner) Record() {
+	sb := r.Sim.Base()
+	row := r.Stats.NumRows()
+	r.Stats.SetNumRows(row + 1)
+	r.Stats.Column("Step").SetInt(sb.Steps, row)
+
+	agents := sb.Agents
+	clusters := metrics.Clusters(agents, metrics.DefaultClusterThreshold)
+	largest := 0.0
+	if len(clusters) > 0 {
+		largest = float64(len(clusters[0])) / float64(len(agents))
+	}
+	r.Stats.Column("Polarization").SetFloat(metrics.Polarization(agents), row)
+	r.Stats.Column("Esteban-Ray").SetFloat(metrics.EstebanRay(agents, metrics.DefaultAlpha, metrics.DefaultBins), row)
+	r.Stats.Column("Clusters").SetFloat(float64(len(clusters)), row)
+	r.Stats.Column("Largest Cluster").SetFloat(largest, row)
+	r.Stats.Column("Value Distance").SetFloat(metrics.ValueDistance(agents), row)
+	r.Stats.Column("Influence Gini").SetFloat(metrics.InfluenceGini(agents), row)
+	for i := range sb.Config.Base().Beliefs {
+		r.Stats.Column(fmt.Sprintf("Belief %d Mean", i)).SetFloat(metrics.Mean(agents, i), row)
+		r.Stats.Column(fmt.Sprintf("Belief %d Bimodality", i)).SetFloat(metrics.Bimodality(agents, i), row)
+	}
+}
+
+// AgentTable returns a table with the current state of each agent
+// in the simulation, with one row per agent.
+func (r *Runner) AgentTable() *table.Table {
+	sb := r.Sim.Base()
+	nb := sb.Config.Base().Beliefs
+	dt := table.New("Agents")
+	dt.AddIntColumn("ID")
+	for _, name := range []string{"Position X", "Position Y", "Velocity X", "Velocity Y", "Influence"} {
+		dt.AddFloat64Column(name)
+	}
+	for i := range nb {
+		dt.AddFloat64Column(fmt.Sprintf("Belief %d", i))
+	}
+	for i := range nb {
+		dt.AddFloat64Column(fmt.Sprintf("Value %d", i))
+	}
+	dt.SetNumRows(len(sb.Agents))
+	for row, a := range sb.Agents {
+		ab := a.Base()
+		dt.Column("ID").SetInt(int(ab.ID), row)
+		dt.Column("Position X").SetFloat(float64(ab.Position.X), row)
+		dt.Column("Position Y").SetFloat(float64(ab.Position.Y), row)
+		dt.Column("Velocity X").SetFloat(float64(ab.Velocity.X), row)
+		dt.Column("Velocity Y").SetFloat(float64(ab.Velocity.Y), row)
+		dt.Column("Influence").SetFloat(float64(ab.Influence), row)
+		for i := range nb {
+			dt.Column(fmt.Sprintf("Belief %d", i)).SetFloat(float64(ab.Beliefs[i]), row)
+			dt.Column(fmt.Sprintf("Value %d", i)).SetFloat(float64(ab.Values[i]), row)
+		}
+	}
+	return dt
+}
+
+// Save saves the statistics and the current state of the agents to files
+// named stats and agents in the given directory, in the given format.
+// It creates the directory if it does not exist.
+func (r *Runner) Save(dir string, format Formats) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	if err := SaveTable(r.Stats, filepath.Join(dir, "stats"), format); err != nil {
+		return err
+	}
+	return SaveTable(r.AgentTable(), filepath.Join(dir, "agents"), format)
+}
+
+// SaveTable saves the given table to the given file in the given format,
+// adding the extension of the format to the filename.
+func SaveTable(dt *table.Table, filename string, format Formats) error {
+	if format == FormatCSV {
+		return dt.SaveCSV(fsx.Filename(filename+".csv"), tensor.Comma, true)
+	}
+	rows := make([]map[string]any, dt.NumRows())
+	for row := range rows {
+		rows[row] = map[string]any{}
+		for i, name := range dt.Columns.Keys {
+			col := dt.ColumnByIndex(i)
+			if col.IsString() {
+				rows[row][name] = col.StringRow(row, 0)
+			} else {
+				rows[row][name] = col.FloatRow(row, 0)
+			}
+		}
+	}
+	b, err := json.MarshalIndent(rows, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename+".json", b, 0644)
+}
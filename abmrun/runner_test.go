@@ -0,0 +1,26 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package abmrun
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/kleroterio/abm/abm"
+	"github.com/kleroterio/abm/sims/basic"
+)
+
+func TestRunnerInterval(t *testing.T) {
+	r := NewRunner(abm.NewSim[basic.Sim, basic.Config]())
+	r.Interval = 3
+	r.Run(10)
+	var steps []int
+	for row := range r.Stats.NumRows() {
+		steps = append(steps, r.Stats.Column("Step").IntRow(row, 0))
+	}
+	if want := []int{0, 3, 6, 9, 10}; !slices.Equal(steps, want) {
+		t.Fatalf("got rows for steps %v, want %v", steps, want)
+	}
+}
@@ -0,0 +1,351 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package abmrun
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"runtime"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"cogentcore.org/core/base/errors"
+	"cogentcore.org/core/base/reflectx"
+	"cogentcore.org/core/enums"
+	"cogentcore.org/lab/table"
+	"github.com/kleroterio/abm/abm"
+	"github.com/kleroterio/abm/stop"
+)
+
+// Samplings are the different ways of sampling points
+// in the parameter space of a [Sweep].
+type Samplings int32 //enums:enum -trim-prefix Sampling
+
+const (
+
+	// SamplingGrid runs every combination of the values of all parameters.
+	SamplingGrid Samplings = iota
+
+	// SamplingLatinHypercube runs [Sweep.Samples] points chosen by Latin
+	// hypercube sampling, in which the range of each parameter is divided
+	// into one stratum per point, and each stratum is sampled exactly once.
+	SamplingLatinHypercube
+)
+
+// Param is a configuration parameter that is varied in a [Sweep].
+// Its values are specified either as a list in [Param.Values]
+// or as a numeric range with [Param.Min], [Param.Max], and [Param.N].
+type Param struct {
+
+	// Field is the name of the configuration field, which can be a path with
+	// . separators for fields within struct fields. Fields of embedded
+	// structs such as [abm.ConfigBase] can be accessed directly by name.
+	Field string
+
+	// Values is the list of values that the parameter takes. The values can
+	// be of any type that can be converted to the type of the field
+	// (for example, strings for enums). If it is empty, the range
+	// from Min to Max is used instead.
+	Values []any
+
+	// Min is the minimum value of the numeric range of the parameter.
+	Min float64
+
+	// Max is the maximum value of the numeric range of the parameter.
+	Max float64
+
+	// N is the number of evenly spaced values from Min to Max (inclusive)
+	// in a grid sweep. Latin hypercube sweeps sample the whole range,
+	// with sampled values truncated for integer fields.
+	N int
+}
+
+// gridValues returns the values of the parameter in a grid sweep.
+func (p *Param) gridValues() []any {
+	if len(p.Values) > 0 {
+		return p.Values
+	}
+	if p.N <= 1 {
+		return []any{p.Min}
+	}
+	vals := make([]any, p.N)
+	for i := range vals {
+		vals[i] = p.Min + (p.Max-p.Min)*float64(i)/float64(p.N-1)
+	}
+	return vals
+}
+
+// sample returns the value of the parameter at the given
+// position in its range (0 to 1) in a Latin hypercube sweep.
+func (p *Param) sample(u float64) any {
+	if len(p.Values) > 0 {
+		return p.Values[min(int(u*float64(len(p.Values))), len(p.Values)-1)]
+	}
+	return p.Min + (p.Max-p.Min)*u
+}
+
+// Sweep is a parameter sweep, which runs a simulation for many
+// combinations of configuration parameter values, with multiple
+// replicates using different seeds at each point.
+type Sweep struct {
+
+	// Params are the parameters to vary.
+	Params []Param
+
+	// Sampling is how the points in the parameter space are chosen.
+	Sampling Samplings
+
+	// Samples is the number of points for [SamplingLatinHypercube].
+	Samples int
+
+	// Replicates is the number of runs at each point. Replicate r uses
+	// [abm.ConfigBase.Seed] = Seed + r, so all points use the same seeds.
+	Replicates int
+
+	// Seed is the seed for Latin hypercube sampling and the base seed
+	// for the replicates.
+	Seed uint64
+
+	// Steps is the number of steps in each run.
+	Steps int
+
+	// Stop, if non-nil, returns a new stopping condition for each run,
+	// which stops the run before [Sweep.Steps] if it is met. The table
+	// from [RunSweep] then has a Stop column with the conditions that
+	// were met, and a Converged column with the time to convergence
+	// from [stop.Result.Converged], which is -1 for runs that did not
+	// stop because of a [stop.Stable] condition.
+	Stop func() stop.Condition
+
+	// Workers is the number of runs executed in parallel.
+	// If it is 0, it is the number of available CPU cores.
+	Workers int
+}
+
+// Validate returns an error describing every invalid setting of the
+// sweep, such as a sweep with no points, or nil if the sweep is valid.
+func (sw *Sweep) Validate() error {
+	var errs []error
+	if sw.Sampling == SamplingLatinHypercube {
+		errs = append(errs, abm.CheckMin("Samples", sw.Samples, 1))
+	}
+	errs = append(errs, abm.CheckMin("Replicates", sw.Replicates, 0), abm.CheckMin("Steps", sw.Steps, 0))
+	for _, p := range sw.Params {
+		if sw.Sampling == SamplingGrid && len(p.Values) == 0 && p.N < 1 {
+			errs = append(errs, fmt.Errorf("%s must have Values or N of at least 1, but N is %d", p.Field, p.N))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Points returns the parameter values of each point in the sweep,
+// with one value for each of [Sweep.Params].
+func (sw *Sweep) Points() [][]any {
+	if sw.Sampling == SamplingLatinHypercube {
+		return sw.latinHypercube()
+	}
+	points := [][]any{{}}
+	for _, p := range sw.Params {
+		var next [][]any
+		for _, pt := range points {
+			for _, v := range p.gridValues() {
+				next = append(next, append(append([]any{}, pt...), v))
+			}
+		}
+		points = next
+	}
+	return points
+}
+
+// latinHypercube returns the points of a Latin hypercube sample.
+func (sw *Sweep) latinHypercube() [][]any {
+	n := sw.Samples
+	rnd := rand.New(rand.NewPCG(sw.Seed, sw.Seed))
+	points := make([][]any, n)
+	for i := range points {
+		points[i] = make([]any, len(sw.Params))
+	}
+	for k := range sw.Params {
+		for i, stratum := range rnd.Perm(n) {
+			u := (float64(stratum) + rnd.Float64()) / float64(n)
+			points[i][k] = sw.Params[k].sample(u)
+		}
+	}
+	return points
+}
+
+// SetParam sets the configuration field with the given name
+// (as in [Param.Field]) to the given value.
+func SetParam(cfg abm.Config, field string, value any) error {
+	fv, err := reflectx.FieldByPath(reflect.ValueOf(cfg), field)
+	if err != nil {
+		return err
+	}
+	return reflectx.SetRobust(reflectx.PointerValue(fv).Interface(), value)
+}
+
+// RunSweep runs the given sweep for a simulation of type S with a
+// configuration of type C, as in [abm.NewSim]. It returns a tidy table
+// with one row per run, containing the point and replicate indexes, the seed,
+// the value of each parameter, and the statistics of the final step of the
+// run as recorded by [Runner], along with the stopping conditions that were
+// met if [Sweep.Stop] is set. Only the statistics of the final step are
+// recorded, and each simulation is discarded as soon as its run ends.
+func RunSweep[S, C any](sw *Sweep) (*table.Table, error) {
+	if err := sw.Validate(); err != nil {
+		return nil, fmt.Errorf("abmrun.RunSweep: invalid sweep: %w", err)
+	}
+	points := sw.Points()
+	reps := max(sw.Replicates, 1)
+	nruns := len(points) * reps
+	runs := make([]*sweepRun, nruns)
+	errs := make([]error, nruns)
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	workers := sw.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	for range min(workers, nruns) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				run := int(next.Add(1) - 1)
+				if run >= nruns {
+					return
+				}
+				runs[run], errs[run] = runPoint[S, C](sw, points[run/reps], run%reps)
+			}
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dt := table.New("Sweep")
+	dt.AddIntColumn("Point")
+	dt.AddIntColumn("Replicate")
+	dt.AddIntColumn("Seed")
+	for _, p := range sw.Params {
+		if isNumeric(runs[0].config, p.Field) {
+			dt.AddFloat64Column(p.Field)
+		} else {
+			dt.AddStringColumn(p.Field)
+		}
+	}
+	if sw.Stop != nil {
+		dt.AddStringColumn("Stop")
+		dt.AddIntColumn("Converged")
+	}
+	// runs can have different statistics (for example, with different numbers
+	// of belief axes), so we use all of them, with missing values set to NaN
+	var stats []string
+	for _, r := range runs {
+		for i := range r.stats.NumColumns() {
+			name := r.stats.ColumnName(i)
+			if !slices.Contains(stats, name) {
+				stats = append(stats, name)
+				dt.AddFloat64Column(name)
+			}
+		}
+	}
+	dt.SetNumRows(nruns)
+	for run, r := range runs {
+		dt.Column("Point").SetInt(run/reps, run)
+		dt.Column("Replicate").SetInt(run%reps, run)
+		dt.Column("Seed").SetInt(int(r.config.Base().Seed), run)
+		for _, p := range sw.Params {
+			fv, _ := reflectx.FieldByPath(reflect.ValueOf(r.config), p.Field)
+			col := dt.Column(p.Field)
+			if col.IsString() {
+				col.SetString(reflectx.ToString(fv.Interface()), run)
+			} else {
+				col.SetFloat(toFloat(fv.Interface()), run)
+			}
+		}
+		if r.result != nil {
+			dt.Column("Stop").SetString(r.result.Reason(), run)
+			dt.Column("Converged").SetInt(r.result.Converged, run)
+		}
+		last := r.stats.NumRows() - 1
+		for _, name := range stats {
+			v := math.NaN()
+			if sc, err := r.stats.ColumnTry(name); err == nil {
+				v = sc.FloatRow(last, 0)
+			}
+			dt.Column(name).SetFloat(v, run)
+		}
+	}
+	return dt, nil
+}
+
+// sweepRun is the outcome of one run of a [Sweep].
+type sweepRun struct {
+
+	// config is the configuration of the run.
+	config abm.Config
+
+	// stats contains the statistics of the final step of the run.
+	stats *table.Table
+
+	// result is the result of the run if [Sweep.Stop] is set.
+	result *stop.Result
+}
+
+// runPoint runs one replicate of the given point of the given sweep,
+// recording only the statistics of the final step.
+func runPoint[S, C any](sw *Sweep, point []any, rep int) (*sweepRun, error) {
+	cfg := any(abm.NewConfig[C]()).(abm.Config)
+	for k, p := range sw.Params {
+		if err := SetParam(cfg, p.Field, point[k]); err != nil {
+			return nil, fmt.Errorf("abmrun.RunSweep: setting %s: %w", p.Field, err)
+		}
+	}
+	cfg.Base().Seed = sw.Seed + uint64(rep)
+	simS, err := abm.NewSimWithConfig[S](cfg)
+	if err != nil {
+		return nil, fmt.Errorf("abmrun.RunSweep: invalid configuration at point %v: %w", point, err)
+	}
+	r := newRunner(any(simS).(abm.Sim))
+	sr := &sweepRun{config: cfg, stats: r.Stats}
+	if sw.Stop == nil {
+		r.Run(sw.Steps)
+		return sr, nil
+	}
+	sr.result = r.RunUntil(stop.NewOr(sw.Stop(), stop.MaxSteps(sw.Steps)))
+	return sr, nil
+}
+
+// toFloat converts the given numeric value to a float64. Float32 values
+// are converted through their shortest decimal representation, so that
+// values such as 0.1 are not shown as 0.10000000149011612.
+func toFloat(v any) float64 {
+	if f, ok := v.(float32); ok {
+		return errors.Log1(strconv.ParseFloat(strconv.FormatFloat(float64(f), 'g', -1, 32), 64))
+	}
+	return errors.Log1(reflectx.ToFloat(v))
+}
+
+// isNumeric returns whether the configuration field with the given
+// name is a number that is not an enum.
+func isNumeric(cfg abm.Config, field string) bool {
+	fv, err := reflectx.FieldByPath(reflect.ValueOf(cfg), field)
+	if err != nil {
+		return false
+	}
+	if _, ok := reflectx.PointerValue(fv).Interface().(enums.Enum); ok {
+		return false
+	}
+	return reflectx.KindIsNumber(fv.Kind())
+}
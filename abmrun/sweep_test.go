@@ -0,0 +1,71 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package abmrun
+
+import (
+	"testing"
+
+	"github.com/kleroterio/abm/sims/basic"
+	"github.com/kleroterio/abm/stop"
+)
+
+func TestRunSweep(t *testing.T) {
+	sw := &Sweep{
+		Params: []Param{
+			{Field: "Population", Values: []any{20, 40}},
+			{Field: "BeliefFilter", Min: 0, Max: 1, N: 3},
+		},
+		Replicates: 2,
+		Steps:      5,
+		Seed:       1,
+	}
+	dt, err := RunSweep[basic.Sim, basic.Config](sw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := dt.NumRows(); n != 12 {
+		t.Fatalf("got %d rows, want 12", n)
+	}
+	for row := range dt.NumRows() {
+		if step := dt.Column("Step").IntRow(row, 0); step != 5 {
+			t.Errorf("row %d has step %d, want 5", row, step)
+		}
+	}
+	if got := dt.Column("Population").FloatRow(11, 0); got != 40 {
+		t.Errorf("last row has population %g, want 40", got)
+	}
+}
+
+func TestRunSweepConverged(t *testing.T) {
+	sw := &Sweep{
+		Params:     []Param{{Field: "Population", Values: []any{20}}},
+		Replicates: 2,
+		Steps:      10,
+		Stop:       func() stop.Condition { return stop.NewStable(1, 3) },
+	}
+	dt, err := RunSweep[basic.Sim, basic.Config](sw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for row := range dt.NumRows() {
+		step, converged := dt.Column("Step").IntRow(row, 0), dt.Column("Converged").IntRow(row, 0)
+		if step != 3 || converged != 0 {
+			t.Errorf("row %d stopped at step %d after converging at %d, want 3 and 0", row, step, converged)
+		}
+	}
+}
+
+func TestSweepValidate(t *testing.T) {
+	sweeps := map[string]*Sweep{
+		"no samples":     {Sampling: SamplingLatinHypercube, Params: []Param{{Field: "BeliefFilter", Max: 1}}},
+		"empty grid":     {Params: []Param{{Field: "BeliefFilter", Max: 1}}},
+		"negative steps": {Steps: -1},
+	}
+	for name, sw := range sweeps {
+		if _, err := RunSweep[basic.Sim, basic.Config](sw); err == nil {
+			t.Errorf("%s: got no error", name)
+		}
+	}
+}
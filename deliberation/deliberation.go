@@ -0,0 +1,181 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package deliberation provides a model of the internal deliberation of
+// small bodies of agents, such as citizens' assemblies and legislatures,
+// as opposed to the spatial random mixing of [abm.SimBase.Step].
+package deliberation
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/kleroterio/abm/abm"
+	"github.com/kleroterio/abm/metrics"
+)
+
+// stream is the stream of the random number generator of a [Deliberation],
+// which ensures that it is independent of [abm.SimBase.Rand].
+const stream = 0x64656c69 // "deli"
+
+// Phase is a phase of a [Deliberation], such as [Discussion] or [Information].
+type Phase interface {
+	fmt.Stringer
+
+	// Run runs the phase for the given deliberation,
+	// updating the beliefs of its members.
+	Run(d *Deliberation) error
+}
+
+// Deliberation is a deliberative process among a small body of agents,
+// consisting of a sequence of phases. The beliefs of the members are
+// changed in place, so the members remain part of their simulation
+// and carry the results of the deliberation with them. Deliberation
+// does not advance [abm.SimBase.Steps] or use [abm.SimBase.Rand].
+type Deliberation struct {
+
+	// Sim is the simulation that the members belong to.
+	Sim abm.Sim
+
+	// Members are the agents that take part in the deliberation,
+	// such as the agents selected by a sortition lottery.
+	Members []abm.Agent
+
+	// Phases are the phases of the deliberation, in order.
+	Phases []Phase
+
+	// Rule, if non-nil, is the rule that determines how beliefs change when
+	// a member hears another member or an information source. Otherwise,
+	// members hear both through [abm.Agent.Receive], which uses
+	// [abm.SimBase.Rule] unless the agent type overrides it.
+	Rule abm.InteractionRule
+
+	// Rand is the random number generator used for forming
+	// groups and choosing speakers.
+	Rand *rand.Rand
+
+	// Snapshots contains a snapshot of the members before the
+	// deliberation, followed by one after each phase.
+	Snapshots []*Snapshot
+
+	// Turns contains the number of speaking turns that each member
+	// has taken in the deliberation. It is reset by [Deliberation.Run],
+	// and made by [Deliberation.Speak] if it does not match the members,
+	// so that phases can also be run on their own.
+	Turns []int
+}
+
+// Snapshot is the state of the members of a [Deliberation] at a point in time.
+type Snapshot struct {
+
+	// Name is the name of the point in time: "Before" for the start of the
+	// deliberation, and otherwise the name of the phase that just ended.
+	Name string
+
+	// Agents contains copies of the members, in the same order as
+	// [Deliberation.Members], which can be passed to the functions
+	// in package metrics. They do not have any connections.
+	Agents []abm.Agent
+}
+
+// New returns a new [Deliberation] among the given members of the given
+// simulation with the given phases, with a random number generator seeded
+// from [abm.ConfigBase.Seed]. The deliberation is therefore reproducible,
+// but independent of the random numbers used by the simulation.
+func New(sim abm.Sim, members []abm.Agent, phases ...Phase) *Deliberation {
+	seed := sim.Base().Config.Base().Seed
+	return &Deliberation{Sim: sim, Members: members, Phases: phases, Rand: rand.New(rand.NewPCG(seed, stream))}
+}
+
+// Run runs all of the phases of the deliberation in order,
+// recording [Deliberation.Snapshots] and [Deliberation.Turns].
+func (d *Deliberation) Run() error {
+	d.Snapshots = []*Snapshot{d.snapshot("Before")}
+	d.Turns = make([]int, len(d.Members))
+	for _, p := range d.Phases {
+		if err := p.Run(d); err != nil {
+			return err
+		}
+		d.Snapshots = append(d.Snapshots, d.snapshot(p.String()))
+	}
+	return nil
+}
+
+// snapshot returns a [Snapshot] of the members with the given name.
+func (d *Deliberation) snapshot(name string) *Snapshot {
+	s := &Snapshot{Name: name, Agents: make([]abm.Agent, len(d.Members))}
+	for i, a := range d.Members {
+		ab := a.Base()
+		s.Agents[i] = &abm.AgentBase{Sim: ab.Sim, ID: ab.ID, Position: ab.Position, Velocity: ab.Velocity,
+			Beliefs: slices.Clone(ab.Beliefs), Values: slices.Clone(ab.Values), Influence: ab.Influence}
+	}
+	return s
+}
+
+// Before returns the snapshot of the members before the deliberation,
+// or nil if it has not been run.
+func (d *Deliberation) Before() *Snapshot {
+	if len(d.Snapshots) == 0 {
+		return nil
+	}
+	return d.Snapshots[0]
+}
+
+// After returns the snapshot of the members after the last phase
+// of the deliberation, or nil if it has not been run.
+func (d *Deliberation) After() *Snapshot {
+	if len(d.Snapshots) == 0 {
+		return nil
+	}
+	return d.Snapshots[len(d.Snapshots)-1]
+}
+
+// Shift returns the change in the mean belief of the members on each
+// belief axis from before to after the deliberation.
+func (d *Deliberation) Shift() []float64 {
+	before, after := d.Before(), d.After()
+	if before == nil {
+		return nil
+	}
+	shift := make([]float64, d.Sim.Base().Config.Base().Beliefs)
+	for i := range shift {
+		shift[i] = metrics.Mean(after.Agents, i) - metrics.Mean(before.Agents, i)
+	}
+	return shift
+}
+
+// Speak has the member at the given index speak to the members at
+// the given indexes, updating the beliefs of each listener.
+// It is intended for use in [Phase] implementations.
+func (d *Deliberation) Speak(speaker int, listeners []int) {
+	if len(d.Turns) != len(d.Members) {
+		d.Turns = make([]int, len(d.Members))
+	}
+	d.Turns[speaker]++
+	s := d.Members[speaker]
+	for _, l := range listeners {
+		if l == speaker {
+			continue
+		}
+		if d.Rule == nil {
+			s.Interact(d.Members[l])
+			continue
+		}
+		d.Inform(l, s.Base())
+	}
+}
+
+// Inform updates the beliefs of the member at the given index as a result
+// of hearing the given source, which need not be an agent in the simulation.
+// It is intended for use in [Phase] implementations.
+func (d *Deliberation) Inform(member int, source *abm.AgentBase) {
+	if d.Rule == nil {
+		d.Members[member].Receive(source)
+		return
+	}
+	target := d.Members[member].Base()
+	d.Rule.Update(target, source, d.Sim.Base().Config.Base())
+	target.ClampBeliefs()
+}
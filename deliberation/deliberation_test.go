@@ -0,0 +1,116 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package deliberation
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/kleroterio/abm/abm"
+	"github.com/kleroterio/abm/sims/basic"
+)
+
+func TestPhaseTurns(t *testing.T) {
+	sim := abm.NewSim[basic.Sim, basic.Config]()
+	members := sim.Agents[:12]
+	discussion := &Discussion{Rounds: 2, GroupSize: 5, Turns: 3, Facilitated: true}
+
+	// a phase can run on its own, without Run
+	d := New(sim, members)
+	if err := discussion.Run(d); err != nil {
+		t.Fatal(err)
+	}
+	want := make([]int, len(members))
+	for i := range want {
+		want[i] = discussion.Rounds * discussion.Turns
+	}
+	if !slices.Equal(d.Turns, want) {
+		t.Errorf("got turns %v, want %v", d.Turns, want)
+	}
+
+	// Run resets the turns
+	info := &Information{Beliefs: make([]float32, sim.Config.Base().Beliefs), Sessions: 1}
+	d = New(sim, members, discussion, info)
+	d.Turns = []int{1}
+	if err := d.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(d.Turns, want) {
+		t.Errorf("got turns %v after Run, want %v", d.Turns, want)
+	}
+	if len(d.Snapshots) != 3 {
+		t.Errorf("got %d snapshots, want 3", len(d.Snapshots))
+	}
+}
+
+func TestUnfacilitatedTurns(t *testing.T) {
+	sim := abm.NewSim[basic.Sim, basic.Config]()
+	members := sim.Agents[:12]
+	for i, a := range members {
+		a.Base().Influence = 0.1
+		if i == 0 {
+			a.Base().Influence = 1.1
+		}
+	}
+	discussion := &Discussion{Rounds: 20, GroupSize: len(members), Turns: 5}
+	d := New(sim, members, discussion)
+	if err := d.Run(); err != nil {
+		t.Fatal(err)
+	}
+	total := 0
+	for _, n := range d.Turns {
+		total += n
+	}
+	if want := discussion.Rounds * discussion.Turns * len(members); total != want {
+		t.Fatalf("got %d turns, want %d", total, want)
+	}
+	// the first member has half of the total influence,
+	// so they should take about half of the turns
+	if got := float64(d.Turns[0]) / float64(total); math.Abs(got-0.5) > 0.05 {
+		t.Errorf("got %v turns, want about half for the first member", d.Turns)
+	}
+}
+
+func TestInformation(t *testing.T) {
+	run := func(influence float32) *Deliberation {
+		sim := abm.NewSim[basic.Sim, basic.Config]()
+		beliefs := make([]float32, sim.Config.Base().Beliefs)
+		for i := range beliefs {
+			beliefs[i] = 1
+		}
+		info := &Information{Beliefs: beliefs, Influence: influence, Sessions: 3}
+		d := New(sim, sim.Agents[:12], info)
+		if err := d.Run(); err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+
+	// the default influence of 0 is treated as 1
+	d := run(0)
+	if !slices.Equal(d.Shift(), run(1).Shift()) {
+		t.Errorf("got shift %v with influence 0, want %v as with influence 1", d.Shift(), run(1).Shift())
+	}
+	for i, shift := range d.Shift() {
+		if shift <= 0 {
+			t.Errorf("got shift %g on axis %d, want a positive shift toward the information", shift, i)
+		}
+	}
+	before, after := d.Before().Agents, d.After().Agents
+	for k := range before {
+		for i, b := range before[k].Base().Beliefs {
+			if a := after[k].Base().Beliefs[i]; a < b {
+				t.Fatalf("member %d moved from %g to %g on axis %d, away from the information", k, b, a, i)
+			}
+		}
+	}
+
+	sim := abm.NewSim[basic.Sim, basic.Config]()
+	info := &Information{Beliefs: make([]float32, sim.Config.Base().Beliefs), Influence: -1, Sessions: 1}
+	if err := info.Run(New(sim, sim.Agents[:12])); err == nil {
+		t.Error("got no error for negative influence")
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package deliberation
+
+import (
+	"fmt"
+
+	"github.com/kleroterio/abm/abm"
+)
+
+// Discussion is a [Phase] in which the members discuss in small groups
+// over several rounds, with the members divided into new random groups
+// in each round so that each member hears a variety of others.
+type Discussion struct {
+
+	// Rounds is the number of rounds of discussion.
+	Rounds int
+
+	// GroupSize is the maximum number of members in each group. The members are
+	// divided into as few groups as possible, with sizes differing by at most one.
+	GroupSize int
+
+	// Turns is the mean number of speaking turns per member in each round.
+	// Each turn, the speaker is heard by every other member of their group.
+	Turns int
+
+	// Facilitated is whether the groups have a facilitator, who equalizes
+	// speaking turns so that every member speaks exactly [Discussion.Turns]
+	// times in each round. Without a facilitator, each speaker is chosen
+	// with probability proportional to their influence, so influential
+	// members dominate the discussion.
+	Facilitated bool
+}
+
+func (dp *Discussion) String() string {
+	if dp.Facilitated {
+		return "Facilitated discussion"
+	}
+	return "Discussion"
+}
+
+func (dp *Discussion) Run(d *Deliberation) error {
+	if err := dp.validate(); err != nil {
+		return err
+	}
+	n := len(d.Members)
+	if n == 0 {
+		return nil
+	}
+	groups := (n + dp.GroupSize - 1) / dp.GroupSize
+	for range dp.Rounds {
+		order := d.Rand.Perm(n)
+		for g := range groups {
+			group := order[g*n/groups : (g+1)*n/groups]
+			if dp.Facilitated {
+				dp.facilitated(d, group)
+			} else {
+				dp.unfacilitated(d, group)
+			}
+		}
+	}
+	return nil
+}
+
+// validate returns an error if the parameters of the discussion are invalid.
+func (dp *Discussion) validate() error {
+	if dp.Rounds < 0 || dp.GroupSize < 1 || dp.Turns < 0 {
+		return fmt.Errorf("deliberation.Discussion: invalid rounds %d, group size %d, or turns %d", dp.Rounds, dp.GroupSize, dp.Turns)
+	}
+	return nil
+}
+
+// facilitated has every member of the given group speak
+// [Discussion.Turns] times, in a random order each time.
+func (dp *Discussion) facilitated(d *Deliberation, group []int) {
+	for range dp.Turns {
+		for _, k := range d.Rand.Perm(len(group)) {
+			d.Speak(group[k], group)
+		}
+	}
+}
+
+// unfacilitated has the given group take [Discussion.Turns] speaking
+// turns per member, with each speaker chosen with probability
+// proportional to their influence.
+func (dp *Discussion) unfacilitated(d *Deliberation, group []int) {
+	total := float32(0)
+	for _, i := range group {
+		total += d.Members[i].Base().Influence
+	}
+	for range dp.Turns * len(group) {
+		if total <= 0 {
+			d.Speak(group[d.Rand.IntN(len(group))], group)
+			continue
+		}
+		speaker := group[len(group)-1]
+		r := d.Rand.Float32() * total
+		for _, i := range group {
+			r -= d.Members[i].Base().Influence
+			if r < 0 {
+				speaker = i
+				break
+			}
+		}
+		d.Speak(speaker, group)
+	}
+}
+
+// Information is a [Phase] in which all members hear the same information,
+// such as expert testimony or briefing materials, represented as a source
+// with fixed beliefs.
+type Information struct {
+
+	// Name is the name of the information, such as "Expert testimony".
+	Name string
+
+	// Beliefs is the position of the information on each belief axis (0 to 1).
+	Beliefs []float32
+
+	// Influence is the influence of the information source, relative to
+	// the influence of the members. It must not be negative, and if it is
+	// 0, it is treated as 1, the maximum initial influence of an agent,
+	// since a source with no influence would have no effect under rules
+	// such as [abm.ExtremeBiasRule].
+	Influence float32
+
+	// Sessions is the number of times that each member hears the information.
+	Sessions int
+}
+
+func (ip *Information) String() string {
+	if ip.Name == "" {
+		return "Information"
+	}
+	return ip.Name
+}
+
+func (ip *Information) Run(d *Deliberation) error {
+	if nb := d.Sim.Base().Config.Base().Beliefs; len(ip.Beliefs) != nb {
+		return fmt.Errorf("deliberation.Information: %s has %d beliefs, but the simulation has %d", ip, len(ip.Beliefs), nb)
+	}
+	if ip.Influence < 0 {
+		return fmt.Errorf("deliberation.Information: %s has negative influence %g", ip, ip.Influence)
+	}
+	influence := ip.Influence
+	if influence == 0 {
+		influence = 1
+	}
+	source := &abm.AgentBase{Sim: d.Sim, Beliefs: ip.Beliefs, Values: ip.Beliefs, Influence: influence}
+	for range ip.Sessions {
+		for i := range d.Members {
+			d.Inform(i, source)
+		}
+	}
+	return nil
+}
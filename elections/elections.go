@@ -0,0 +1,155 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package elections provides elections in which agents vote for candidates
+// with platforms in the belief space of the agents, with results
+// determined by a variety of voting methods.
+package elections
+
+//go:generate core generate
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/kleroterio/abm/abm"
+)
+
+// Candidate is a candidate in an [Election].
+type Candidate struct {
+
+	// Name is the name of the candidate.
+	Name string
+
+	// Platform is the position of the candidate on each belief axis (0 to 1),
+	// in the same belief space as [abm.AgentBase.Beliefs].
+	Platform []float32
+}
+
+// CandidatesFromAgents returns candidates for the given agents, with
+// platforms equal to their current beliefs.
+func CandidatesFromAgents(agents []abm.Agent) []Candidate {
+	cands := make([]Candidate, len(agents))
+	for i, a := range agents {
+		ab := a.Base()
+		cands[i] = Candidate{Name: fmt.Sprintf("Agent %d", ab.ID), Platform: slices.Clone(ab.Beliefs)}
+	}
+	return cands
+}
+
+// Ballot is the ballot of one voter in an [Election].
+// It contains both a ranking and approvals, so that
+// it can be counted with any voting method.
+type Ballot struct {
+
+	// Distances contains the belief distance from the voter to each candidate,
+	// as measured by [abm.BeliefDistance].
+	Distances []float32
+
+	// Ranking contains the indexes of all of the candidates from the most
+	// preferred (closest) to the least preferred (farthest). Candidates at
+	// the same distance are ranked in the order of their indexes.
+	Ranking []int
+
+	// Approved contains whether the voter approves of each candidate.
+	Approved []bool
+}
+
+// NewBallot returns the ballot of a voter with the given beliefs for the
+// given candidates, approving of every candidate within the given
+// belief distance of the voter.
+func NewBallot(beliefs []float32, candidates []Candidate, approval float32) Ballot {
+	b := Ballot{
+		Distances: make([]float32, len(candidates)),
+		Ranking:   make([]int, len(candidates)),
+		Approved:  make([]bool, len(candidates)),
+	}
+	for i, c := range candidates {
+		b.Distances[i] = distance(beliefs, c.Platform)
+		b.Ranking[i] = i
+		b.Approved[i] = b.Distances[i] <= approval
+	}
+	slices.SortStableFunc(b.Ranking, func(i, j int) int {
+		return cmp.Compare(b.Distances[i], b.Distances[j])
+	})
+	return b
+}
+
+// Prefers returns whether the ballot ranks candidate i above candidate j.
+func (b *Ballot) Prefers(i, j int) bool {
+	return slices.Index(b.Ranking, i) < slices.Index(b.Ranking, j)
+}
+
+// Election is an election in which voters vote for candidates.
+// An election is counted with [Election.Tally].
+type Election struct {
+
+	// Candidates are the candidates in the election.
+	Candidates []Candidate
+
+	// Ballots are the ballots of the voters.
+	Ballots []Ballot
+}
+
+// NewElection returns a new election in which the given agents vote for the
+// given candidates based on the distance between their beliefs and the
+// platforms of the candidates, approving of every candidate within the
+// given belief distance (as in [NewBallot]).
+func NewElection(voters []abm.Agent, candidates []Candidate, approval float32) *Election {
+	e := &Election{Candidates: candidates, Ballots: make([]Ballot, len(voters))}
+	for i, a := range voters {
+		e.Ballots[i] = NewBallot(a.Base().Beliefs, candidates, approval)
+	}
+	return e
+}
+
+// Pairwise returns the pairwise preference matrix of the election, in which
+// the value at [i][j] is the number of voters who prefer candidate i to candidate j.
+func (e *Election) Pairwise() [][]int {
+	n := len(e.Candidates)
+	d := make([][]int, n)
+	for i := range d {
+		d[i] = make([]int, n)
+	}
+	for _, b := range e.Ballots {
+		for k, i := range b.Ranking {
+			for _, j := range b.Ranking[k+1:] {
+				d[i][j]++
+			}
+		}
+	}
+	return d
+}
+
+// CondorcetWinner returns the index of the candidate that is preferred to
+// every other candidate by a majority of voters, or -1 if there is none.
+func (e *Election) CondorcetWinner() int {
+	d := e.Pairwise()
+	for i := range d {
+		wins := true
+		for j := range d {
+			if i != j && d[i][j] <= d[j][i] {
+				wins = false
+				break
+			}
+		}
+		if wins {
+			return i
+		}
+	}
+	return -1
+}
+
+// distance returns the normalized distance between the given beliefs
+// and platform, in the same way as [abm.BeliefDistance].
+func distance(beliefs, platform []float32) float32 {
+	sum := 0.0
+	for i, b := range beliefs {
+		d := float64(b - platform[i])
+		sum += d * d
+	}
+	return float32(math.Sqrt(sum / float64(len(beliefs))))
+}
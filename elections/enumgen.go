@@ -0,0 +1,48 @@
+// Code generated by "core generate"; DO NOT EDIT.
+
+package elections
+
+import (
+	"cogentcore.org/core/enums"
+)
+
+var _MethodsValues = []Methods{0, 1, 2, 3, 4, 5, 6}
+
+// MethodsN is the highest valid value for type Methods, plus one.
+const MethodsN Methods = 7
+
+var _MethodsValueMap = map[string]Methods{`Plurality`: 0, `TwoRound`: 1, `InstantRunoff`: 2, `Approval`: 3, `Borda`: 4, `Copeland`: 5, `Schulze`: 6}
+
+var _MethodsDescMap = map[Methods]string{0: `MethodPlurality elects the candidate ranked first by the most voters.`, 1: `MethodTwoRound elects the candidate ranked first by a majority of voters if there is one, and otherwise holds a runoff between the two candidates ranked first by the most voters.`, 2: `MethodInstantRunoff repeatedly eliminates the candidate ranked first by the fewest voters, transferring their votes to their next choices, until a candidate is ranked first by a majority of voters.`, 3: `MethodApproval elects the candidate approved by the most voters.`, 4: `MethodBorda gives each candidate n-1 points for every first place ranking, n-2 for every second place ranking, and so on (for n candidates), and elects the candidate with the most points.`, 5: `MethodCopeland elects the candidate who wins the most pairwise contests against other candidates, with ties counting as half a win. It always elects the Condorcet winner if there is one.`, 6: `MethodSchulze elects the candidate who beats the most other candidates through the strongest paths of pairwise wins. It always elects the Condorcet winner if there is one.`}
+
+var _MethodsMap = map[Methods]string{0: `Plurality`, 1: `TwoRound`, 2: `InstantRunoff`, 3: `Approval`, 4: `Borda`, 5: `Copeland`, 6: `Schulze`}
+
+// String returns the string representation of this Methods value.
+func (i Methods) String() string { return enums.String(i, _MethodsMap) }
+
+// SetString sets the Methods value from its string representation,
+// and returns an error if the string is invalid.
+func (i *Methods) SetString(s string) error {
+	return enums.SetString(i, s, _MethodsValueMap, "Methods")
+}
+
+// Int64 returns the Methods value as an int64.
+func (i Methods) Int64() int64 { return int64(i) }
+
+// SetInt64 sets the Methods value from an int64.
+func (i *Methods) SetInt64(in int64) { *i = Methods(in) }
+
+// Desc returns the description of the Methods value.
+func (i Methods) Desc() string { return enums.Desc(i, _MethodsDescMap) }
+
+// MethodsValues returns all possible values for the type Methods.
+func MethodsValues() []Methods { return _MethodsValues }
+
+// Values returns all possible values for the type Methods.
+func (i Methods) Values() []enums.Enum { return enums.Values(_MethodsValues) }
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i Methods) MarshalText() ([]byte, error) { return []byte(i.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *Methods) UnmarshalText(text []byte) error { return enums.UnmarshalText(i, text, "Methods") }
@@ -0,0 +1,316 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package elections
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// Methods are the different voting methods for counting an [Election].
+type Methods int32 //enums:enum -trim-prefix Method
+
+const (
+
+	// MethodPlurality elects the candidate ranked first by the most voters.
+	MethodPlurality Methods = iota
+
+	// MethodTwoRound elects the candidate ranked first by a majority of voters
+	// if there is one, and otherwise holds a runoff between the two candidates
+	// ranked first by the most voters.
+	MethodTwoRound
+
+	// MethodInstantRunoff repeatedly eliminates the candidate ranked first by
+	// the fewest voters, transferring their votes to their next choices, until
+	// a candidate is ranked first by a majority of voters.
+	MethodInstantRunoff
+
+	// MethodApproval elects the candidate approved by the most voters.
+	MethodApproval
+
+	// MethodBorda gives each candidate n-1 points for every first place ranking,
+	// n-2 for every second place ranking, and so on (for n candidates),
+	// and elects the candidate with the most points.
+	MethodBorda
+
+	// MethodCopeland elects the candidate who wins the most pairwise contests
+	// against other candidates, with ties counting as half a win.
+	// It always elects the Condorcet winner if there is one.
+	MethodCopeland
+
+	// MethodSchulze elects the candidate who beats the most other candidates
+	// through the strongest paths of pairwise wins. It always elects the
+	// Condorcet winner if there is one.
+	MethodSchulze
+)
+
+// Round is one round of counting in an [Election].
+type Round struct {
+
+	// Scores contains the score of each candidate in the round, such as the
+	// number of votes, approvals, points, or pairwise wins, depending on the
+	// voting method. Candidates who were eliminated in earlier rounds
+	// have a score of NaN.
+	Scores []float64
+
+	// Eliminated contains the indexes of the candidates
+	// that were eliminated after the round.
+	Eliminated []int
+}
+
+// Result is the result of counting an [Election] with a voting method.
+// Ties are broken in favor of the candidate with the lowest index.
+type Result struct {
+
+	// Method is the voting method.
+	Method Methods
+
+	// Winner is the index of the winning candidate,
+	// or -1 if there are no candidates.
+	Winner int
+
+	// Ranking contains the indexes of all of the candidates in the order of
+	// the result, starting with the winner. Eliminated candidates are ranked
+	// below the others, with candidates eliminated in later rounds ranked
+	// higher, and candidates eliminated in the same round ranked by score.
+	Ranking []int
+
+	// Rounds contains the rounds of counting, in order.
+	Rounds []Round
+
+	// Pairwise is the pairwise preference matrix from [Election.Pairwise]
+	// for [MethodCopeland], and the strengths of the strongest paths
+	// between candidates for [MethodSchulze].
+	Pairwise [][]int
+}
+
+// Tally counts the election with the given voting method.
+func (e *Election) Tally(method Methods) *Result {
+	switch method {
+	case MethodTwoRound:
+		return e.TwoRound()
+	case MethodInstantRunoff:
+		return e.InstantRunoff()
+	case MethodApproval:
+		return e.Approval()
+	case MethodBorda:
+		return e.Borda()
+	case MethodCopeland:
+		return e.Copeland()
+	case MethodSchulze:
+		return e.Schulze()
+	}
+	return e.Plurality()
+}
+
+// Plurality counts the election with [MethodPlurality].
+func (e *Election) Plurality() *Result {
+	return singleRound(MethodPlurality, e.firstChoices(nil))
+}
+
+// TwoRound counts the election with [MethodTwoRound].
+func (e *Election) TwoRound() *Result {
+	res := &Result{Method: MethodTwoRound}
+	first := e.firstChoices(nil)
+	ranking := rankScores(first)
+	if len(ranking) < 2 || first[ranking[0]] > float64(len(e.Ballots))/2 {
+		res.Rounds = []Round{{Scores: first}}
+		res.finish(ranking)
+		return res
+	}
+	finalists := ranking[:2]
+	res.Rounds = []Round{{Scores: first, Eliminated: slices.Clone(ranking[2:])}}
+	second := e.firstChoices(eliminatedExcept(len(e.Candidates), finalists))
+	res.Rounds = append(res.Rounds, Round{Scores: second})
+	final := rankScores(second)[:2]
+	res.finish(append(final, ranking[2:]...))
+	return res
+}
+
+// InstantRunoff counts the election with [MethodInstantRunoff].
+func (e *Election) InstantRunoff() *Result {
+	res := &Result{Method: MethodInstantRunoff}
+	n := len(e.Candidates)
+	eliminated := make([]bool, n)
+	var order []int // eliminated candidates in order of elimination
+	for {
+		scores := e.firstChoices(eliminated)
+		ranking := rankScores(scores)
+		if len(ranking) <= 1 || scores[ranking[0]] > float64(len(e.Ballots))/2 {
+			res.Rounds = append(res.Rounds, Round{Scores: scores})
+			res.finish(append(ranking, reversed(order)...))
+			return res
+		}
+		last := ranking[len(ranking)-1]
+		eliminated[last] = true
+		order = append(order, last)
+		res.Rounds = append(res.Rounds, Round{Scores: scores, Eliminated: []int{last}})
+	}
+}
+
+// Approval counts the election with [MethodApproval].
+func (e *Election) Approval() *Result {
+	scores := make([]float64, len(e.Candidates))
+	for _, b := range e.Ballots {
+		for i, ok := range b.Approved {
+			if ok {
+				scores[i]++
+			}
+		}
+	}
+	return singleRound(MethodApproval, scores)
+}
+
+// Borda counts the election with [MethodBorda].
+func (e *Election) Borda() *Result {
+	n := len(e.Candidates)
+	scores := make([]float64, n)
+	for _, b := range e.Ballots {
+		for k, i := range b.Ranking {
+			scores[i] += float64(n - 1 - k)
+		}
+	}
+	return singleRound(MethodBorda, scores)
+}
+
+// Copeland counts the election with [MethodCopeland].
+func (e *Election) Copeland() *Result {
+	d := e.Pairwise()
+	res := singleRound(MethodCopeland, pairwiseWins(d))
+	res.Pairwise = d
+	return res
+}
+
+// Schulze counts the election with [MethodSchulze].
+func (e *Election) Schulze() *Result {
+	d := e.Pairwise()
+	n := len(d)
+	// p is the strength of the strongest path from i to j,
+	// computed with a variant of the Floyd–Warshall algorithm
+	p := make([][]int, n)
+	for i := range p {
+		p[i] = make([]int, n)
+		for j := range p[i] {
+			if i != j && d[i][j] > d[j][i] {
+				p[i][j] = d[i][j]
+			}
+		}
+	}
+	for k := range n {
+		for i := range n {
+			if i == k {
+				continue
+			}
+			for j := range n {
+				if j == i || j == k {
+					continue
+				}
+				p[i][j] = max(p[i][j], min(p[i][k], p[k][j]))
+			}
+		}
+	}
+	scores := make([]float64, n)
+	for i := range n {
+		for j := range n {
+			if i != j && p[i][j] > p[j][i] {
+				scores[i]++
+			}
+		}
+	}
+	res := singleRound(MethodSchulze, scores)
+	res.Pairwise = p
+	return res
+}
+
+// firstChoices returns the number of ballots that rank each candidate first
+// among the candidates that are not eliminated (eliminated may be nil).
+// Eliminated candidates have a score of NaN.
+func (e *Election) firstChoices(eliminated []bool) []float64 {
+	scores := make([]float64, len(e.Candidates))
+	for i := range scores {
+		if eliminated != nil && eliminated[i] {
+			scores[i] = math.NaN()
+		}
+	}
+	for _, b := range e.Ballots {
+		for _, i := range b.Ranking {
+			if eliminated == nil || !eliminated[i] {
+				scores[i]++
+				break
+			}
+		}
+	}
+	return scores
+}
+
+// pairwiseWins returns the number of pairwise contests that each candidate
+// wins in the given pairwise preference matrix, with ties counting as half.
+func pairwiseWins(d [][]int) []float64 {
+	scores := make([]float64, len(d))
+	for i := range d {
+		for j := range d {
+			if i == j {
+				continue
+			}
+			switch {
+			case d[i][j] > d[j][i]:
+				scores[i]++
+			case d[i][j] == d[j][i]:
+				scores[i] += 0.5
+			}
+		}
+	}
+	return scores
+}
+
+// singleRound returns the result of a voting method
+// with a single round with the given scores.
+func singleRound(method Methods, scores []float64) *Result {
+	res := &Result{Method: method, Rounds: []Round{{Scores: scores}}}
+	res.finish(rankScores(scores))
+	return res
+}
+
+// finish sets the ranking and winner of the result.
+func (res *Result) finish(ranking []int) {
+	res.Ranking = ranking
+	res.Winner = -1
+	if len(ranking) > 0 {
+		res.Winner = ranking[0]
+	}
+}
+
+// rankScores returns the indexes of the candidates that do not have a score of
+// NaN in descending order of score, with ties in ascending order of index.
+func rankScores(scores []float64) []int {
+	var ranking []int
+	for i, s := range scores {
+		if !math.IsNaN(s) {
+			ranking = append(ranking, i)
+		}
+	}
+	slices.SortStableFunc(ranking, func(i, j int) int {
+		return cmp.Compare(scores[j], scores[i])
+	})
+	return ranking
+}
+
+// eliminatedExcept returns a slice indicating that all of the given number
+// of candidates except for the given ones are eliminated.
+func eliminatedExcept(n int, remaining []int) []bool {
+	eliminated := make([]bool, n)
+	for i := range eliminated {
+		eliminated[i] = !slices.Contains(remaining, i)
+	}
+	return eliminated
+}
+
+// reversed returns a reversed copy of the given slice.
+func reversed(s []int) []int {
+	r := slices.Clone(s)
+	slices.Reverse(r)
+	return r
+}
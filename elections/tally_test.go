@@ -0,0 +1,192 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package elections
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/kleroterio/abm/abm"
+)
+
+// voters is a group of voters with the same ballot in a test profile.
+type voters struct {
+
+	// count is the number of voters in the group.
+	count int
+
+	// ranking is the ranking of the candidates by the voters.
+	ranking []int
+
+	// approve is the number of candidates at the top
+	// of the ranking that the voters approve of.
+	approve int
+}
+
+// profile returns an election with the given number
+// of candidates and groups of voters.
+func profile(n int, groups ...voters) *Election {
+	e := &Election{Candidates: make([]Candidate, n)}
+	for _, g := range groups {
+		for range g.count {
+			b := Ballot{Ranking: g.ranking, Approved: make([]bool, n)}
+			for _, i := range g.ranking[:g.approve] {
+				b.Approved[i] = true
+			}
+			e.Ballots = append(e.Ballots, b)
+		}
+	}
+	return e
+}
+
+// tennessee returns the profile of the standard example of the choice of
+// the capital of Tennessee, with the candidates Memphis (0), Nashville (1),
+// Chattanooga (2), and Knoxville (3).
+func tennessee() *Election {
+	return profile(4,
+		voters{42, []int{0, 1, 2, 3}, 1},
+		voters{26, []int{1, 2, 3, 0}, 2},
+		voters{15, []int{2, 3, 1, 0}, 2},
+		voters{17, []int{3, 2, 1, 0}, 3},
+	)
+}
+
+// schulze returns the profile of the standard example for [MethodSchulze],
+// with 45 voters and 5 candidates that have no Condorcet winner.
+func schulze() *Election {
+	const a, b, c, d, e = 0, 1, 2, 3, 4
+	return profile(5,
+		voters{5, []int{a, c, b, e, d}, 1},
+		voters{5, []int{a, d, e, c, b}, 1},
+		voters{8, []int{b, e, d, a, c}, 1},
+		voters{3, []int{c, a, b, e, d}, 1},
+		voters{7, []int{c, a, e, b, d}, 1},
+		voters{2, []int{c, b, a, d, e}, 1},
+		voters{7, []int{d, c, e, b, a}, 1},
+		voters{8, []int{e, b, a, d, c}, 1},
+	)
+}
+
+// checkResult fails the test if the result does not
+// have the given ranking and scores in each round.
+func checkResult(t *testing.T, res *Result, ranking []int, scores ...[]float64) {
+	t.Helper()
+	if res.Winner != ranking[0] || !slices.Equal(res.Ranking, ranking) {
+		t.Errorf("%v: got winner %d and ranking %v, want %v", res.Method, res.Winner, res.Ranking, ranking)
+	}
+	if len(res.Rounds) != len(scores) {
+		t.Fatalf("%v: got %d rounds, want %d", res.Method, len(res.Rounds), len(scores))
+	}
+	for r, want := range scores {
+		got := res.Rounds[r].Scores
+		same := slices.EqualFunc(got, want, func(g, w float64) bool {
+			return g == w || math.IsNaN(g) && math.IsNaN(w)
+		})
+		if !same {
+			t.Errorf("%v: round %d: got scores %v, want %v", res.Method, r, got, want)
+		}
+	}
+}
+
+func TestTennessee(t *testing.T) {
+	e := tennessee()
+	nan := math.NaN()
+	checkResult(t, e.Tally(MethodPlurality), []int{0, 1, 3, 2}, []float64{42, 26, 15, 17})
+	checkResult(t, e.Tally(MethodTwoRound), []int{1, 0, 3, 2},
+		[]float64{42, 26, 15, 17}, []float64{42, 58, nan, nan})
+	ir := e.Tally(MethodInstantRunoff)
+	checkResult(t, ir, []int{3, 0, 1, 2},
+		[]float64{42, 26, 15, 17}, []float64{42, 26, nan, 32}, []float64{42, nan, nan, 58})
+	if !slices.Equal(ir.Rounds[0].Eliminated, []int{2}) || !slices.Equal(ir.Rounds[1].Eliminated, []int{1}) {
+		t.Errorf("InstantRunoff: got eliminations %v and %v, want [2] and [1]", ir.Rounds[0].Eliminated, ir.Rounds[1].Eliminated)
+	}
+	checkResult(t, e.Tally(MethodApproval), []int{2, 1, 0, 3}, []float64{42, 43, 58, 32})
+	checkResult(t, e.Tally(MethodBorda), []int{1, 2, 0, 3}, []float64{126, 194, 173, 107})
+	checkResult(t, e.Tally(MethodCopeland), []int{1, 2, 3, 0}, []float64{0, 3, 2, 1})
+	checkResult(t, e.Tally(MethodSchulze), []int{1, 2, 3, 0}, []float64{0, 3, 2, 1})
+	if w := e.CondorcetWinner(); w != 1 {
+		t.Errorf("got Condorcet winner %d, want 1", w)
+	}
+}
+
+func TestSchulze(t *testing.T) {
+	e := schulze()
+	if w := e.CondorcetWinner(); w != -1 {
+		t.Errorf("got Condorcet winner %d, want none", w)
+	}
+	wantD := [][]int{
+		{0, 20, 26, 30, 22},
+		{25, 0, 16, 33, 18},
+		{19, 29, 0, 17, 24},
+		{15, 12, 28, 0, 14},
+		{23, 27, 21, 31, 0},
+	}
+	if d := e.Pairwise(); !slices.EqualFunc(d, wantD, slices.Equal) {
+		t.Errorf("got pairwise matrix %v, want %v", d, wantD)
+	}
+	res := e.Tally(MethodSchulze)
+	checkResult(t, res, []int{4, 0, 2, 1, 3}, []float64{3, 1, 2, 0, 4})
+	wantP := [][]int{
+		{0, 28, 28, 30, 24},
+		{25, 0, 28, 33, 24},
+		{25, 29, 0, 29, 24},
+		{25, 28, 28, 0, 24},
+		{25, 28, 28, 31, 0},
+	}
+	if !slices.EqualFunc(res.Pairwise, wantP, slices.Equal) {
+		t.Errorf("got strongest paths %v, want %v", res.Pairwise, wantP)
+	}
+}
+
+func TestTies(t *testing.T) {
+	e := profile(3,
+		voters{2, []int{2, 1, 0}, 1},
+		voters{2, []int{1, 2, 0}, 1},
+	)
+	checkResult(t, e.Tally(MethodPlurality), []int{1, 2, 0}, []float64{0, 2, 2})
+	checkResult(t, e.Tally(MethodCopeland), []int{1, 2, 0}, []float64{0, 1.5, 1.5})
+
+	// a majority in the first round makes a second round unnecessary
+	e = profile(3,
+		voters{3, []int{2, 0, 1}, 1},
+		voters{2, []int{0, 1, 2}, 1},
+	)
+	checkResult(t, e.Tally(MethodTwoRound), []int{2, 0, 1}, []float64{2, 0, 3})
+
+	empty := &Election{}
+	for _, method := range MethodsValues() {
+		if res := empty.Tally(method); res.Winner != -1 {
+			t.Errorf("%v: got winner %d with no candidates, want -1", method, res.Winner)
+		}
+	}
+}
+
+func TestNewElection(t *testing.T) {
+	cands := []Candidate{
+		{Name: "Left", Platform: []float32{0.1}},
+		{Name: "Center", Platform: []float32{0.5}},
+		{Name: "Right", Platform: []float32{0.9}},
+	}
+	agents := []abm.Agent{
+		&abm.AgentBase{Beliefs: []float32{0}},
+		&abm.AgentBase{Beliefs: []float32{0.35}},
+		&abm.AgentBase{Beliefs: []float32{0.8}},
+	}
+	e := NewElection(agents, cands, 0.2)
+	wantRankings := [][]int{{0, 1, 2}, {1, 0, 2}, {2, 1, 0}}
+	wantApproved := [][]bool{{true, false, false}, {false, true, false}, {false, false, true}}
+	for i, b := range e.Ballots {
+		if !slices.Equal(b.Ranking, wantRankings[i]) || !slices.Equal(b.Approved, wantApproved[i]) {
+			t.Errorf("ballot %d: got ranking %v and approvals %v, want %v and %v", i, b.Ranking, b.Approved, wantRankings[i], wantApproved[i])
+		}
+	}
+	if !e.Ballots[1].Prefers(1, 2) || e.Ballots[1].Prefers(2, 0) {
+		t.Errorf("ballot 1 has the wrong preferences")
+	}
+	if w := e.CondorcetWinner(); w != 1 {
+		t.Errorf("got Condorcet winner %d, want 1", w)
+	}
+}
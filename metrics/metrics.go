@@ -0,0 +1,373 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package metrics provides metrics of polarization, consensus, and
+// inequality in populations of agents. The metrics work on any number
+// of belief axes and do not depend on a GUI, so they can be used in
+// both interactive and headless simulations.
+package metrics
+
+import (
+	"encoding/binary"
+	"math"
+	"slices"
+
+	"github.com/kleroterio/abm/abm"
+)
+
+const (
+	// DefaultAlpha is the default polarization sensitivity
+	// for [EstebanRay], which is at the top of its valid range.
+	DefaultAlpha = 1.6
+
+	// DefaultBins is the default number of bins per belief axis
+	// used to group agents for [EstebanRay].
+	DefaultBins = 10
+
+	// DefaultClusterThreshold is the default belief distance threshold
+	// for [Clusters].
+	DefaultClusterThreshold = 0.1
+)
+
+// numBeliefs returns the number of belief axes of the given agents.
+func numBeliefs(agents []abm.Agent) int {
+	if len(agents) == 0 {
+		return 0
+	}
+	return len(agents[0].Base().Beliefs)
+}
+
+// Mean returns the mean belief of the given agents on the given belief axis.
+func Mean(agents []abm.Agent, axis int) float64 {
+	if len(agents) == 0 {
+		return math.NaN()
+	}
+	sum := 0.0
+	for _, a := range agents {
+		sum += float64(a.Base().Beliefs[axis])
+	}
+	return sum / float64(len(agents))
+}
+
+// Variance returns the population variance of the beliefs of the
+// given agents on the given belief axis.
+func Variance(agents []abm.Agent, axis int) float64 {
+	mean := Mean(agents, axis)
+	sum := 0.0
+	for _, a := range agents {
+		d := float64(a.Base().Beliefs[axis]) - mean
+		sum += d * d
+	}
+	return sum / float64(len(agents))
+}
+
+// Range returns the difference between the largest and smallest
+// beliefs of the given agents on the given belief axis.
+func Range(agents []abm.Agent, axis int) float64 {
+	if len(agents) == 0 {
+		return math.NaN()
+	}
+	lo, hi := float32(math.Inf(1)), float32(math.Inf(-1))
+	for _, a := range agents {
+		b := a.Base().Beliefs[axis]
+		lo, hi = min(lo, b), max(hi, b)
+	}
+	return float64(hi - lo)
+}
+
+// Polarization returns the variance-based polarization of the given agents,
+// which is the square root of the sum of the variances of their beliefs on
+// each belief axis (the total standard deviation of beliefs).
+func Polarization(agents []abm.Agent) float64 {
+	variance := 0.0
+	for i := range numBeliefs(agents) {
+		variance += Variance(agents, i)
+	}
+	return math.Sqrt(variance)
+}
+
+// EstebanRay returns the Esteban–Ray polarization index of the given agents,
+// with the given polarization sensitivity alpha (0 to 1.6). Agents are grouped
+// by dividing each belief axis into the given number of equal bins, with each
+// group located at the mean belief of its members, and the distance between
+// groups measured as in [abm.BeliefDistance]. The index is normalized so that
+// it is 1 for two equally sized groups at opposite extremes of every axis.
+// It takes time proportional to the square of the number of nonempty groups,
+// which is at most the smaller of the number of agents and bins^axes.
+func EstebanRay(agents []abm.Agent, alpha float64, bins int) float64 {
+	nb := numBeliefs(agents)
+	if nb == 0 {
+		return 0
+	}
+	type group struct {
+		size  float64
+		means []float64
+	}
+	var groups []*group
+	byKey := map[string]*group{}
+	var key []byte
+	for _, a := range agents {
+		beliefs := a.Base().Beliefs
+		key = key[:0]
+		for _, b := range beliefs {
+			key = binary.AppendUvarint(key, uint64(min(max(int(b*float32(bins)), 0), bins-1)))
+		}
+		g := byKey[string(key)]
+		if g == nil {
+			g = &group{means: make([]float64, nb)}
+			byKey[string(key)] = g
+			groups = append(groups, g)
+		}
+		g.size++
+		for i, b := range beliefs {
+			g.means[i] += float64(b)
+		}
+	}
+	n := float64(len(agents))
+	for _, g := range groups {
+		for i := range g.means {
+			g.means[i] /= g.size
+		}
+		g.size /= n
+	}
+	index := 0.0
+	for _, g := range groups {
+		for _, h := range groups {
+			sum := 0.0
+			for i := range nb {
+				d := g.means[i] - h.means[i]
+				sum += d * d
+			}
+			index += math.Pow(g.size, 1+alpha) * h.size * math.Sqrt(sum/float64(nb))
+		}
+	}
+	return index * math.Pow(2, 1+alpha)
+}
+
+// Bimodality returns the sample bimodality coefficient of the beliefs of the
+// given agents on the given belief axis, which is computed from the skewness
+// and excess kurtosis of the beliefs. Values above 5/9 (≈0.555) indicate a
+// bimodal distribution. It returns NaN for fewer than four agents or if all
+// of the beliefs are the same.
+func Bimodality(agents []abm.Agent, axis int) float64 {
+	n := float64(len(agents))
+	if n < 4 {
+		return math.NaN()
+	}
+	mean := Mean(agents, axis)
+	var m2, m3, m4 float64
+	for _, a := range agents {
+		d := float64(a.Base().Beliefs[axis]) - mean
+		d2 := d * d
+		m2 += d2
+		m3 += d2 * d
+		m4 += d2 * d2
+	}
+	m2 /= n
+	m3 /= n
+	m4 /= n
+	if m2 == 0 {
+		return math.NaN()
+	}
+	skew := m3 / math.Pow(m2, 1.5) * math.Sqrt(n*(n-1)) / (n - 2)
+	kurt := ((n+1)*(m4/(m2*m2)-3) + 6) * (n - 1) / ((n - 2) * (n - 3))
+	return (skew*skew + 1) / (kurt + 3*(n-1)*(n-1)/((n-2)*(n-3)))
+}
+
+// Clusters returns the opinion clusters of the given agents, which are
+// the groups of agents connected by chains of agents whose beliefs are
+// within the given distance of each other, as measured by
+// [abm.BeliefDistance]. Each cluster contains the indices of its agents
+// in ascending order, and the clusters are sorted from largest to smallest.
+//
+// The agents are first grouped into the cells of a grid in belief space
+// with a width of the threshold on every axis. All agents in the same cell
+// are within the threshold of each other, so only agents in nearby cells
+// need to be compared, and only until the cells are found to be connected.
+// This makes it close to linear in the number of agents, even when the
+// beliefs are concentrated.
+func Clusters(agents []abm.Agent, threshold float32) [][]int {
+	n := len(agents)
+	if n == 0 {
+		return nil
+	}
+	nb := numBeliefs(agents)
+	cells := beliefCells(agents, threshold)
+	parent := make([]int, len(cells))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	// cells are connected if any of their agents are within the threshold,
+	// which is only possible if the sum of the squared numbers of cells
+	// between them on each axis is at most the number of axes; the cells
+	// are sorted by their coordinates, so we only need to look ahead
+	// until the gap on the first axis is too large
+	maxGap := 1 + int(math.Sqrt(float64(nb)))
+	for k, c := range cells {
+		if threshold <= 0 {
+			break // only agents in the same cell can be connected
+		}
+		for l := k + 1; l < len(cells); l++ {
+			d := cells[l]
+			if d.coords[0]-c.coords[0] > maxGap {
+				break
+			}
+			rc, rd := find(k), find(l)
+			if rc == rd || !cellsNear(c, d, nb) || !cellsConnected(agents, c, d, threshold) {
+				continue
+			}
+			parent[max(rc, rd)] = min(rc, rd)
+		}
+	}
+	byRoot := map[int]int{}
+	var clusters [][]int
+	for k, c := range cells {
+		r := find(k)
+		ci, ok := byRoot[r]
+		if !ok {
+			ci = len(clusters)
+			byRoot[r] = ci
+			clusters = append(clusters, nil)
+		}
+		clusters[ci] = append(clusters[ci], c.agents...)
+	}
+	for _, c := range clusters {
+		slices.Sort(c)
+	}
+	slices.SortStableFunc(clusters, func(a, b []int) int {
+		if len(a) != len(b) {
+			return len(b) - len(a)
+		}
+		return a[0] - b[0]
+	})
+	return clusters
+}
+
+// beliefCell is a cell of a grid in belief space used by [Clusters].
+type beliefCell struct {
+
+	// coords are the integer coordinates of the cell on each belief axis.
+	coords []int
+
+	// agents are the indices of the agents in the cell.
+	agents []int
+}
+
+// beliefCells returns the nonempty cells of a grid in belief space with
+// the given width on every axis that contain the given agents, sorted
+// by their coordinates. If the width is not positive, each cell contains
+// the agents with exactly the same beliefs.
+func beliefCells(agents []abm.Agent, width float32) []*beliefCell {
+	byKey := map[string]*beliefCell{}
+	var cells []*beliefCell
+	var key []byte
+	for i, a := range agents {
+		beliefs := a.Base().Beliefs
+		key = key[:0]
+		for _, b := range beliefs {
+			key = binary.AppendVarint(key, cellCoord(b, width))
+		}
+		c := byKey[string(key)]
+		if c == nil {
+			c = &beliefCell{coords: make([]int, len(beliefs))}
+			for k, b := range beliefs {
+				c.coords[k] = int(cellCoord(b, width))
+			}
+			byKey[string(key)] = c
+			cells = append(cells, c)
+		}
+		c.agents = append(c.agents, i)
+	}
+	slices.SortFunc(cells, func(a, b *beliefCell) int {
+		return slices.Compare(a.coords, b.coords)
+	})
+	return cells
+}
+
+// cellCoord returns the coordinate of the grid cell with the given width
+// that contains the given belief, or the bits of the belief if the width
+// is not positive.
+func cellCoord(b, width float32) int64 {
+	if width <= 0 {
+		return int64(math.Float32bits(b))
+	}
+	return int64(math.Floor(float64(b) / float64(width)))
+}
+
+// cellsNear returns whether the given cells are close enough for any of
+// their agents to be within the width of the cells of each other,
+// given the number of belief axes.
+func cellsNear(c, d *beliefCell, nb int) bool {
+	sum := 0
+	for i, x := range c.coords {
+		gap := max(d.coords[i]-x, x-d.coords[i], 1) - 1
+		sum += gap * gap
+	}
+	return sum <= nb
+}
+
+// cellsConnected returns whether any agent in one of the given cells
+// is within the given threshold of an agent in the other.
+func cellsConnected(agents []abm.Agent, c, d *beliefCell, threshold float32) bool {
+	for _, i := range c.agents {
+		for _, j := range d.agents {
+			if abm.BeliefDistance(agents[i].Base(), agents[j].Base()) <= threshold {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ValueDistance returns the mean distance between the beliefs and values
+// of the given agents, measured in the same way as [abm.BeliefDistance].
+func ValueDistance(agents []abm.Agent) float64 {
+	if len(agents) == 0 {
+		return math.NaN()
+	}
+	total := 0.0
+	for _, a := range agents {
+		ab := a.Base()
+		sum := 0.0
+		for i, b := range ab.Beliefs {
+			d := float64(b - ab.Values[i])
+			sum += d * d
+		}
+		if len(ab.Beliefs) > 0 {
+			total += math.Sqrt(sum / float64(len(ab.Beliefs)))
+		}
+	}
+	return total / float64(len(agents))
+}
+
+// InfluenceGini returns the Gini coefficient of the influence of the given
+// agents, which is 0 when all agents have the same influence and approaches
+// 1 as influence becomes concentrated in a single agent.
+func InfluenceGini(agents []abm.Agent) float64 {
+	n := len(agents)
+	if n == 0 {
+		return math.NaN()
+	}
+	influences := make([]float64, n)
+	for i, a := range agents {
+		influences[i] = float64(a.Base().Influence)
+	}
+	slices.Sort(influences)
+	sum, weighted := 0.0, 0.0
+	for i, x := range influences {
+		sum += x
+		weighted += float64(2*i-n+1) * x
+	}
+	if sum == 0 {
+		return 0
+	}
+	return weighted / (float64(n) * sum)
+}
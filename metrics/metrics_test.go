@@ -0,0 +1,116 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package metrics
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/kleroterio/abm/abm"
+)
+
+// randomAgents returns n agents with nb beliefs drawn from a normal
+// distribution with the given mean and standard deviation, clamped to 0 to 1.
+func randomAgents(rnd *rand.Rand, n, nb int, mean, sd float64) []abm.Agent {
+	agents := make([]abm.Agent, n)
+	for i := range agents {
+		beliefs := make([]float32, nb)
+		for k := range beliefs {
+			beliefs[k] = float32(min(max(mean+sd*rnd.NormFloat64(), 0), 1))
+		}
+		agents[i] = &abm.AgentBase{Beliefs: beliefs}
+	}
+	return agents
+}
+
+// bruteClusters returns the result of [Clusters] computed by
+// comparing every pair of agents.
+func bruteClusters(agents []abm.Agent, threshold float32) [][]int {
+	n := len(agents)
+	cluster := make([]int, n)
+	for i := range cluster {
+		cluster[i] = -1
+	}
+	var clusters [][]int
+	for i := range n {
+		if cluster[i] >= 0 {
+			continue
+		}
+		c := len(clusters)
+		cluster[i] = c
+		members := []int{i}
+		for k := 0; k < len(members); k++ {
+			for j := range n {
+				if cluster[j] < 0 && abm.BeliefDistance(agents[members[k]].Base(), agents[j].Base()) <= threshold {
+					cluster[j] = c
+					members = append(members, j)
+				}
+			}
+		}
+		slices.Sort(members)
+		clusters = append(clusters, members)
+	}
+	slices.SortStableFunc(clusters, func(a, b []int) int {
+		return len(b) - len(a)
+	})
+	return clusters
+}
+
+func TestClusters(t *testing.T) {
+	rnd := rand.New(rand.NewPCG(1, 2))
+	for _, nb := range []int{1, 2, 3, 7} {
+		for _, sd := range []float64{0.02, 0.1, 0.5} {
+			agents := randomAgents(rnd, 400, nb, 0.5, sd)
+			for _, threshold := range []float32{0, 0.01, 0.05, 0.1, 0.3} {
+				got := Clusters(agents, threshold)
+				want := bruteClusters(agents, threshold)
+				if !slices.EqualFunc(got, want, slices.Equal) {
+					t.Fatalf("nb=%d, sd=%g, threshold=%g: got %d clusters, want %d", nb, sd, threshold, len(got), len(want))
+				}
+			}
+		}
+	}
+}
+
+func TestClustersConcentrated(t *testing.T) {
+	if testing.Short() {
+		t.Skip("large population")
+	}
+	agents := randomAgents(rand.New(rand.NewPCG(3, 4)), 100_000, 2, 0.5, 0.05)
+	clusters := Clusters(agents, DefaultClusterThreshold)
+	if len(clusters) != 1 {
+		t.Fatalf("got %d clusters, want 1", len(clusters))
+	}
+}
+
+func TestEstebanRay(t *testing.T) {
+	extremes := []abm.Agent{
+		&abm.AgentBase{Beliefs: []float32{0, 0}},
+		&abm.AgentBase{Beliefs: []float32{1, 1}},
+	}
+	if got := EstebanRay(extremes, DefaultAlpha, DefaultBins); math.Abs(got-1) > 1e-9 {
+		t.Errorf("got %g for two opposite extremes, want 1", got)
+	}
+	same := []abm.Agent{
+		&abm.AgentBase{Beliefs: []float32{0.3, 0.3}},
+		&abm.AgentBase{Beliefs: []float32{0.3, 0.3}},
+	}
+	if got := EstebanRay(same, DefaultAlpha, DefaultBins); got != 0 {
+		t.Errorf("got %g for identical agents, want 0", got)
+	}
+
+	// with many axes, the groups must still be distinguished
+	// by the bin on every axis
+	const nb = 40
+	a, b := make([]float32, nb), make([]float32, nb)
+	b[0] = 1
+	many := []abm.Agent{&abm.AgentBase{Beliefs: a}, &abm.AgentBase{Beliefs: b}}
+	want := math.Sqrt(1.0 / nb)
+	if got := EstebanRay(many, DefaultAlpha, DefaultBins); math.Abs(got-want) > 1e-9 {
+		t.Errorf("got %g for agents differing on the first of %d axes, want %g", got, nb, want)
+	}
+}
@@ -13,11 +13,14 @@ type Sim struct {
 	abm.SimBase
 }
 
-func (s *Sim) Init() {
+func (s *Sim) Init() error {
+	if err := s.Config.Validate(); err != nil {
+		return err
+	}
 	s.Agents = make([]abm.Agent, s.Config.(*Config).Population)
 	for i := range s.Agents {
 		s.Agents[i] = &abm.AgentBase{}
 	}
 
-	s.Base().Init()
+	return s.Base().Init()
 }
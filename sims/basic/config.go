@@ -4,8 +4,12 @@
 
 package basic
 
-import "github.com/kleroterio/abm/abm"
+import (
+	"cogentcore.org/core/base/errors"
+	"github.com/kleroterio/abm/abm"
+)
 
+// Config is the configuration for a basic [Sim].
 type Config struct {
 
 	// Population is the number of citizens in the simulation.
@@ -13,3 +17,10 @@ type Config struct {
 
 	abm.ConfigBase
 }
+
+func (c *Config) Validate() error {
+	return errors.Join(
+		abm.CheckMin("Population", c.Population, 1),
+		c.ConfigBase.Validate(),
+	)
+}
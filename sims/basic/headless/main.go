@@ -0,0 +1,22 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Command headless runs the basic simulation without a GUI
+// and saves the results to files. Any field of [basic.Config] can be set
+// with a flag of the same name, such as -BeliefFilter 0.2, and the other
+// flags are described in [abmrun.Command].
+package main
+
+import (
+	"log"
+
+	"github.com/kleroterio/abm/abmrun"
+	"github.com/kleroterio/abm/sims/basic"
+)
+
+func main() {
+	if err := abmrun.Main[basic.Sim, basic.Config](); err != nil {
+		log.Fatal(err)
+	}
+}
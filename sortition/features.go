@@ -0,0 +1,123 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortition
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/kleroterio/abm/abm"
+)
+
+// Feature is a feature of agents with a set of categories, such as
+// a range of beliefs, a spatial region, or a custom attribute,
+// which is used for stratification and quotas.
+type Feature struct {
+
+	// Name is the name of the feature.
+	Name string
+
+	// Categories are the names of the categories of the feature.
+	Categories []string
+
+	// Category returns the index of the category of the given agent
+	// in [Feature.Categories].
+	Category func(a abm.Agent) int
+}
+
+// Members returns the indexes of the agents in the given pool
+// that are in each category of the feature.
+func (f *Feature) Members(pool []abm.Agent) [][]int {
+	members := make([][]int, len(f.Categories))
+	for i, a := range pool {
+		c := f.Category(a)
+		members[c] = append(members[c], i)
+	}
+	return members
+}
+
+// BeliefQuantiles returns a [Feature] that divides agents into the given
+// number of categories by the quantiles of the beliefs on the given belief
+// axis of the agents in the given pool, so that each category contains about
+// the same number of agents in the pool. It returns an error if n is not positive.
+func BeliefQuantiles(pool []abm.Agent, axis, n int) (Feature, error) {
+	if n <= 0 {
+		return Feature{}, fmt.Errorf("sortition.BeliefQuantiles: cannot divide agents into %d categories", n)
+	}
+	beliefs := make([]float32, len(pool))
+	for i, a := range pool {
+		beliefs[i] = a.Base().Beliefs[axis]
+	}
+	slices.Sort(beliefs)
+	cuts := make([]float32, n-1)
+	if len(beliefs) > 0 {
+		for k := range cuts {
+			cuts[k] = beliefs[(k+1)*len(beliefs)/n]
+		}
+	}
+	f := Feature{Name: fmt.Sprintf("Belief %d", axis)}
+	for k := range n {
+		f.Categories = append(f.Categories, fmt.Sprintf("Belief %d quantile %d", axis, k+1))
+	}
+	f.Category = func(a abm.Agent) int {
+		// agents at a cut point are in the higher category
+		b := a.Base().Beliefs[axis]
+		return sort.Search(len(cuts), func(k int) bool { return cuts[k] > b })
+	}
+	return f, nil
+}
+
+// Regions returns a [Feature] that divides agents into categories by
+// the spatial region that they are in, with the simulation space divided
+// into a grid of nx by ny equal regions.
+func Regions(nx, ny int) Feature {
+	f := Feature{Name: "Region"}
+	for y := range ny {
+		for x := range nx {
+			f.Categories = append(f.Categories, fmt.Sprintf("Region (%d, %d)", x, y))
+		}
+	}
+	f.Category = func(a abm.Agent) int {
+		pos := a.Base().Position
+		x := min(max(int(pos.X*float32(nx)), 0), nx-1)
+		y := min(max(int(pos.Y*float32(ny)), 0), ny-1)
+		return y*nx + x
+	}
+	return f
+}
+
+// Cross returns a [Feature] whose categories are all of the
+// combinations of the categories of the given features,
+// for stratification by multiple features at once.
+func Cross(features ...Feature) Feature {
+	names := make([]string, len(features))
+	for i, f := range features {
+		names[i] = f.Name
+	}
+	res := Feature{Name: strings.Join(names, " × "), Categories: []string{""}}
+	for _, f := range features {
+		var cats []string
+		for _, prev := range res.Categories {
+			for _, c := range f.Categories {
+				if prev == "" {
+					cats = append(cats, c)
+				} else {
+					cats = append(cats, prev+", "+c)
+				}
+			}
+		}
+		res.Categories = cats
+	}
+	res.Category = func(a abm.Agent) int {
+		c := 0
+		for _, f := range features {
+			c = c*len(f.Categories) + f.Category(a)
+		}
+		return c
+	}
+	return res
+}
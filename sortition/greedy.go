@@ -0,0 +1,184 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortition
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/kleroterio/abm/abm"
+)
+
+// maxGreedyAttempts is the maximum number of attempts of the greedy algorithm
+// to select an assembly that satisfies the quotas.
+const maxGreedyAttempts = 100
+
+// Greedy selects an assembly of k agents from the given pool that satisfies
+// the given quotas with the legacy greedy algorithm used by assembly organizers
+// before fair algorithms such as [Leximin]. It repeatedly selects a random agent
+// from the category whose minimum quota is hardest to meet (the one with the
+// highest ratio of remaining required agents to remaining available agents),
+// and removes agents in categories whose maximum quota has been met from the
+// pool, starting over if it fails to meet the quotas. This can give some
+// agents much lower selection probabilities than others.
+//
+// The selection probabilities of the greedy algorithm cannot be computed
+// exactly, so they are estimated by running it the given number of additional
+// times. If trials is 0, [Selection.Probabilities] is nil. Trials that fail
+// to satisfy the quotas are counted in [Selection.FailedTrials] and left out
+// of the estimates, and it returns an error if all of them fail.
+func (l *Lottery) Greedy(pool []abm.Agent, k int, quotas []Quota, trials int) (*Selection, error) {
+	if k < 0 || k > len(pool) {
+		return nil, fmt.Errorf("sortition.Lottery.Greedy: cannot select %d agents from a pool of %d", k, len(pool))
+	}
+	for i := range quotas {
+		if err := quotas[i].validate(); err != nil {
+			return nil, err
+		}
+	}
+	g := &greedy{lottery: l, k: k, quotas: quotas, categories: make([][]int, len(pool))}
+	for i, a := range pool {
+		g.categories[i] = make([]int, len(quotas))
+		for q := range quotas {
+			g.categories[i][q] = quotas[q].Feature.Category(a)
+		}
+	}
+	selected := g.select1()
+	if selected == nil {
+		return nil, fmt.Errorf("sortition.Lottery.Greedy: failed to satisfy the quotas in %d attempts", maxGreedyAttempts)
+	}
+	s := &Selection{Pool: pool, Selected: selected}
+	if trials <= 0 {
+		return s, nil
+	}
+	s.Probabilities = make([]float64, len(pool))
+	for range trials {
+		trial := g.select1()
+		if trial == nil {
+			s.FailedTrials++
+			continue
+		}
+		for _, i := range trial {
+			s.Probabilities[i]++
+		}
+	}
+	if s.FailedTrials == trials {
+		return nil, fmt.Errorf("sortition.Lottery.Greedy: all %d trials failed to satisfy the quotas", trials)
+	}
+	for i := range s.Probabilities {
+		s.Probabilities[i] /= float64(trials - s.FailedTrials)
+	}
+	return s, nil
+}
+
+// greedy is the state of the greedy algorithm for a pool.
+type greedy struct {
+	lottery *Lottery
+	k       int
+	quotas  []Quota
+
+	// categories contains the category of each agent for each quota.
+	categories [][]int
+}
+
+// select1 returns the indexes of the agents selected by the greedy algorithm
+// in ascending order, or nil if all attempts to satisfy the quotas fail.
+func (g *greedy) select1() []int {
+	for range maxGreedyAttempts {
+		if selected := g.attempt(); selected != nil {
+			slices.Sort(selected)
+			return selected
+		}
+	}
+	return nil
+}
+
+// attempt makes one attempt to select an assembly with the greedy algorithm,
+// returning the indexes of the selected agents, or nil if it fails.
+func (g *greedy) attempt() []int {
+	n := len(g.categories)
+	available := make([]bool, n)
+	counts := make([][]int, len(g.quotas))    // selected agents in each category
+	remaining := make([][]int, len(g.quotas)) // available agents in each category
+	for q, quota := range g.quotas {
+		counts[q] = make([]int, len(quota.Min))
+		remaining[q] = make([]int, len(quota.Min))
+	}
+	for i := range n {
+		available[i] = true
+		for q, c := range g.categories[i] {
+			remaining[q][c]++
+		}
+	}
+	remove := func(i int) {
+		available[i] = false
+		for q, c := range g.categories[i] {
+			remaining[q][c]--
+		}
+	}
+	// removeFull removes the available agents in the given category
+	// if its maximum has been met, so no more can be selected from it
+	removeFull := func(q, c int) {
+		if counts[q][c] < g.quotas[q].Max[c] {
+			return
+		}
+		for j := range n {
+			if available[j] && g.categories[j][q] == c {
+				remove(j)
+			}
+		}
+	}
+	for q, quota := range g.quotas {
+		for c := range quota.Max {
+			removeFull(q, c)
+		}
+	}
+
+	var selected []int
+	candidates := make([]int, 0, n)
+	for len(selected) < g.k {
+		// find the category whose minimum is hardest to meet
+		bestQ, bestC, bestRatio := -1, -1, 0.0
+		for q, quota := range g.quotas {
+			for c, lo := range quota.Min {
+				need := lo - counts[q][c]
+				if need <= 0 {
+					continue
+				}
+				if remaining[q][c] == 0 {
+					return nil
+				}
+				ratio := float64(need) / float64(remaining[q][c])
+				if ratio > bestRatio {
+					bestQ, bestC, bestRatio = q, c, ratio
+				}
+			}
+		}
+		candidates = candidates[:0]
+		for i := range n {
+			if available[i] && (bestQ < 0 || g.categories[i][bestQ] == bestC) {
+				candidates = append(candidates, i)
+			}
+		}
+		if len(candidates) == 0 {
+			return nil
+		}
+		i := candidates[g.lottery.Rand.IntN(len(candidates))]
+		selected = append(selected, i)
+		remove(i)
+		for q, c := range g.categories[i] {
+			counts[q][c]++
+			removeFull(q, c)
+		}
+	}
+	for q, quota := range g.quotas {
+		for c, lo := range quota.Min {
+			if counts[q][c] < lo || counts[q][c] > quota.Max[c] {
+				return nil
+			}
+		}
+	}
+	return selected
+}
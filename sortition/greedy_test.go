@@ -0,0 +1,50 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortition
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestGreedy(t *testing.T) {
+	pool, quotas := testQuotas(t)
+	l := &Lottery{Rand: rand.New(rand.NewPCG(1, 3))}
+	s, err := l.Greedy(pool, 12, quotas, 500)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkQuotas(t, s, 12, quotas)
+	sum := 0.0
+	for _, p := range s.Probabilities {
+		sum += p
+	}
+	if s.FailedTrials > 0 || math.Abs(sum-12) > 1e-9 {
+		t.Errorf("got %d failed trials and probabilities summing to %g, want 0 and 12", s.FailedTrials, sum)
+	}
+}
+
+func TestGreedyMax(t *testing.T) {
+	pool := randomPool(rand.New(rand.NewPCG(1, 2)), 40, 1)
+	regions := Regions(2, 1)
+	quotas := []Quota{{Feature: regions, Min: []int{0, 0}, Max: []int{0, 10}}}
+	l := &Lottery{Rand: rand.New(rand.NewPCG(1, 3))}
+	s, err := l.Greedy(pool, 5, quotas, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkQuotas(t, s, 5, quotas)
+	for i, a := range pool {
+		if regions.Category(a) == 0 && s.Probabilities[i] != 0 {
+			t.Fatalf("agent %d in a category with a maximum of 0 has probability %g", i, s.Probabilities[i])
+		}
+	}
+
+	// the quotas cannot be satisfied with more than 10 agents
+	if _, err := l.Greedy(pool, 15, quotas, 0); err == nil {
+		t.Error("got no error for unsatisfiable quotas")
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortition
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/kleroterio/abm/abm"
+)
+
+// Acceptance is a model of the probability that an agent accepts an
+// invitation to be in the pool for an assembly, which can depend on
+// the beliefs, values, and influence of the agent. The probability is
+// a linear function of those attributes, unless [Acceptance.Func] is set,
+// clamped to the range from 0 to 1.
+type Acceptance struct {
+
+	// Base is the probability of acceptance for an agent with
+	// moderate beliefs and values and no influence.
+	Base float64
+
+	// Extremity is the change in the probability of acceptance for each
+	// unit of the extremity of the beliefs of an agent, which is 0 for
+	// beliefs of 0.5 on every axis and 1 for beliefs of 0 or 1 on every axis.
+	// For example, politically interested people with strong beliefs are
+	// more likely to accept invitations.
+	Extremity float64
+
+	// ValueExtremity is the change in the probability of acceptance
+	// for each unit of the extremity of the values of an agent.
+	ValueExtremity float64
+
+	// Influence is the change in the probability of acceptance
+	// for each unit of the influence of an agent.
+	Influence float64
+
+	// Func, if non-nil, returns the probability that the given agent
+	// accepts an invitation, instead of the linear model.
+	Func func(a abm.Agent) float64
+}
+
+// Probability returns the probability that the given agent accepts an invitation.
+func (ac *Acceptance) Probability(a abm.Agent) float64 {
+	var p float64
+	if ac.Func != nil {
+		p = ac.Func(a)
+	} else {
+		ab := a.Base()
+		p = ac.Base + ac.Extremity*extremity(ab.Beliefs) + ac.ValueExtremity*extremity(ab.Values) + ac.Influence*float64(ab.Influence)
+	}
+	return min(max(p, 0), 1)
+}
+
+// Invitation is the result of inviting agents from a population
+// to be in the pool for an assembly with [Lottery.Invite].
+type Invitation struct {
+
+	// Population is the population of agents that were invited from.
+	Population []abm.Agent
+
+	// Invited contains the indexes in [Invitation.Population]
+	// of the agents that were invited, in ascending order.
+	Invited []int
+
+	// Accepted contains the indexes in [Invitation.Population]
+	// of the agents that accepted, in ascending order.
+	Accepted []int
+
+	// Probabilities contains the probability that each agent in
+	// [Invitation.Population] accepts an invitation.
+	Probabilities []float64
+}
+
+// Invite invites n agents from the given population chosen by simple random
+// sampling, each of whom accepts with the probability given by the given
+// acceptance model. Selection algorithms such as [Lottery.Stratified] can then
+// be run on [Invitation.Pool], and [MeasureBias] can be used to measure how
+// self-selection skews the pool and the resulting assembly.
+func (l *Lottery) Invite(population []abm.Agent, n int, acceptance *Acceptance) (*Invitation, error) {
+	invited, err := l.Simple(population, n)
+	if err != nil {
+		return nil, fmt.Errorf("sortition.Lottery.Invite: %w", err)
+	}
+	inv := &Invitation{Population: population, Invited: invited.Selected, Probabilities: make([]float64, len(population))}
+	for i, a := range population {
+		inv.Probabilities[i] = acceptance.Probability(a)
+	}
+	for _, i := range inv.Invited {
+		if l.Rand.Float64() < inv.Probabilities[i] {
+			inv.Accepted = append(inv.Accepted, i)
+		}
+	}
+	return inv, nil
+}
+
+// Pool returns the agents that accepted the invitation,
+// which form the pool for selecting an assembly.
+func (inv *Invitation) Pool() []abm.Agent {
+	pool := make([]abm.Agent, len(inv.Accepted))
+	for k, i := range inv.Accepted {
+		pool[k] = inv.Population[i]
+	}
+	return pool
+}
+
+// ResponseRate returns the proportion of invited agents that accepted.
+func (inv *Invitation) ResponseRate() float64 {
+	if len(inv.Invited) == 0 {
+		return 0
+	}
+	return float64(len(inv.Accepted)) / float64(len(inv.Invited))
+}
+
+// Bias measures how the composition of a sample of agents, such as the pool
+// of agents that accepted invitations or an assembly selected from it,
+// differs from the population that it was drawn from.
+type Bias struct {
+
+	// BeliefShift contains the mean belief of the sample minus
+	// the mean belief of the population on each belief axis.
+	BeliefShift []float64
+
+	// ExtremityShift is the mean extremity of the beliefs of the sample
+	// minus that of the population (see [Acceptance.Extremity]).
+	ExtremityShift float64
+
+	// InfluenceShift is the mean influence of the sample
+	// minus that of the population.
+	InfluenceShift float64
+
+	// Features contains the total variation distance between the proportions
+	// of the sample and the population in the categories of each feature given
+	// to [MeasureBias], which is the proportion of the sample that would have
+	// to change categories to match the population (0 to 1).
+	Features []float64
+}
+
+// MeasureBias returns the [Bias] of the given sample of agents relative to the
+// given population, such as [abm.SimBase.Agents], with the composition
+// compared in the categories of the given features. If the sample or the
+// population is empty, there is nothing to compare, so every shift and
+// distance is 0.
+func MeasureBias(population, sample []abm.Agent, features ...Feature) *Bias {
+	pop, smp := summarize(population), summarize(sample)
+	b := &Bias{
+		BeliefShift: make([]float64, len(pop.beliefs)),
+		Features:    make([]float64, len(features)),
+	}
+	if len(population) == 0 || len(sample) == 0 {
+		return b
+	}
+	b.ExtremityShift = smp.extremity - pop.extremity
+	b.InfluenceShift = smp.influence - pop.influence
+	for i := range b.BeliefShift {
+		if i < len(smp.beliefs) {
+			b.BeliefShift[i] = smp.beliefs[i] - pop.beliefs[i]
+		} else {
+			b.BeliefShift[i] = math.NaN()
+		}
+	}
+	for k, f := range features {
+		pm, sm := f.Members(population), f.Members(sample)
+		tv := 0.0
+		for c := range pm {
+			tv += math.Abs(share(len(sm[c]), len(sample)) - share(len(pm[c]), len(population)))
+		}
+		b.Features[k] = tv / 2
+	}
+	return b
+}
+
+// summary contains summary statistics of a group of agents for [MeasureBias].
+type summary struct {
+	beliefs   []float64
+	extremity float64
+	influence float64
+}
+
+// summarize returns the summary statistics of the given agents.
+func summarize(agents []abm.Agent) summary {
+	var s summary
+	for _, a := range agents {
+		ab := a.Base()
+		if s.beliefs == nil {
+			s.beliefs = make([]float64, len(ab.Beliefs))
+		}
+		for i, b := range ab.Beliefs {
+			s.beliefs[i] += float64(b)
+		}
+		s.extremity += extremity(ab.Beliefs)
+		s.influence += float64(ab.Influence)
+	}
+	n := float64(len(agents))
+	for i := range s.beliefs {
+		s.beliefs[i] /= n
+	}
+	s.extremity /= n
+	s.influence /= n
+	return s
+}
+
+// extremity returns the extremity of the given beliefs or values, which is
+// the root mean square of their distances from 0.5, scaled to be from 0 to 1.
+func extremity(xs []float32) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, x := range xs {
+		d := 2 * (float64(x) - 0.5)
+		sum += d * d
+	}
+	return math.Sqrt(sum / float64(len(xs)))
+}
+
+// share returns n/total, or 0 if total is 0.
+func share(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total)
+}
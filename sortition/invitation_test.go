@@ -0,0 +1,154 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortition
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/kleroterio/abm/abm"
+)
+
+func TestInvite(t *testing.T) {
+	pool := randomPool(rand.New(rand.NewPCG(1, 2)), 1000, 2)
+	acceptance := &Acceptance{Base: 0.3}
+	invite := func() *Invitation {
+		l := &Lottery{Rand: rand.New(rand.NewPCG(1, 3))}
+		inv, err := l.Invite(pool, 500, acceptance)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return inv
+	}
+	inv := invite()
+	if len(inv.Invited) != 500 || !slices.IsSorted(inv.Invited) || !slices.IsSorted(inv.Accepted) {
+		t.Fatalf("got %d invited agents %v and accepted agents %v, want 500 in ascending order", len(inv.Invited), inv.Invited, inv.Accepted)
+	}
+	for _, i := range inv.Accepted {
+		if _, ok := slices.BinarySearch(inv.Invited, i); !ok {
+			t.Fatalf("agent %d accepted but was not invited", i)
+		}
+	}
+	again := invite()
+	if !slices.Equal(inv.Invited, again.Invited) || !slices.Equal(inv.Accepted, again.Accepted) {
+		t.Error("got different invitations with the same seed")
+	}
+
+	p := inv.Pool()
+	if len(p) != len(inv.Accepted) {
+		t.Fatalf("got a pool of %d agents, want %d", len(p), len(inv.Accepted))
+	}
+	for k, i := range inv.Accepted {
+		if p[k] != pool[i] {
+			t.Fatalf("pool agent %d is %d, want %d", k, p[k].Base().ID, i)
+		}
+	}
+	if got, want := inv.ResponseRate(), float64(len(inv.Accepted))/500; got != want {
+		t.Errorf("got response rate %g, want %g", got, want)
+	}
+	if math.Abs(inv.ResponseRate()-0.3) > 0.05 {
+		t.Errorf("got response rate %g, want about 0.3", inv.ResponseRate())
+	}
+
+	l := &Lottery{Rand: rand.New(rand.NewPCG(1, 3))}
+	if _, err := l.Invite(pool, 1001, acceptance); err == nil {
+		t.Error("got no error for inviting more agents than the population")
+	}
+}
+
+func TestAcceptanceRate(t *testing.T) {
+	pool := randomPool(rand.New(rand.NewPCG(1, 2)), 20000, 1)
+	l := &Lottery{Rand: rand.New(rand.NewPCG(1, 3))}
+
+	// acceptance proportional to belief, so the expected response rate is
+	// E[b] = 1/2 and the expected mean belief of the pool is E[b²]/E[b] = 2/3
+	acceptance := &Acceptance{Func: func(a abm.Agent) float64 { return float64(a.Base().Beliefs[0]) }}
+	inv, err := l.Invite(pool, 10000, acceptance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(inv.ResponseRate()-0.5) > 0.02 {
+		t.Errorf("got response rate %g, want about 0.5", inv.ResponseRate())
+	}
+	b := MeasureBias(pool, inv.Pool())
+	if math.Abs(b.BeliefShift[0]-(2.0/3-0.5)) > 0.02 {
+		t.Errorf("got belief shift %g, want about 1/6", b.BeliefShift[0])
+	}
+
+	// acceptance increasing with extremity, clamped to from 0 to 1
+	acceptance = &Acceptance{Base: -0.5, Extremity: 2}
+	inv, err = l.Invite(pool, 10000, acceptance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, a := range pool {
+		e := math.Abs(2 * (float64(a.Base().Beliefs[0]) - 0.5))
+		want := min(max(-0.5+2*e, 0), 1)
+		if math.Abs(inv.Probabilities[i]-want) > 1e-6 {
+			t.Fatalf("agent %d with extremity %g has probability %g, want %g", i, e, inv.Probabilities[i], want)
+		}
+	}
+	// the extremity is uniform, so the expected response rate is
+	// the mean of min(max(2e - 1/2, 0), 1) over e from 0 to 1, which is 1/2
+	if math.Abs(inv.ResponseRate()-0.5) > 0.02 {
+		t.Errorf("got response rate %g, want about 1/2", inv.ResponseRate())
+	}
+	for _, i := range inv.Accepted {
+		if inv.Probabilities[i] == 0 {
+			t.Fatalf("agent %d accepted with probability 0", i)
+		}
+	}
+
+	// Func is clamped too
+	for _, p := range []float64{-1, 2} {
+		acceptance = &Acceptance{Func: func(a abm.Agent) float64 { return p }}
+		inv, err = l.Invite(pool, 100, acceptance)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := min(max(p, 0), 1)
+		if inv.Probabilities[0] != want || inv.ResponseRate() != want {
+			t.Errorf("got probability %g and response rate %g for Func returning %g, want %g", inv.Probabilities[0], inv.ResponseRate(), p, want)
+		}
+	}
+}
+
+func TestMeasureBias(t *testing.T) {
+	population := make([]abm.Agent, 4)
+	for i := range population {
+		population[i] = &abm.AgentBase{ID: uint64(i), Beliefs: []float32{0.2 * float32(i+1)}, Influence: float32(i)}
+	}
+	high := Feature{Name: "High", Categories: []string{"Low", "High"}, Category: func(a abm.Agent) int {
+		if a.Base().Beliefs[0] > 0.5 {
+			return 1
+		}
+		return 0
+	}}
+	odd := Feature{Name: "Odd", Categories: []string{"Even", "Odd"}, Category: func(a abm.Agent) int {
+		return int(a.Base().ID % 2)
+	}}
+
+	// beliefs 0.6 and 0.8 against 0.2, 0.4, 0.6, and 0.8,
+	// with the same extremities of 0.2 and 0.6
+	b := MeasureBias(population, population[2:], high, odd)
+	check := func(name string, got, want float64) {
+		t.Helper()
+		if math.Abs(got-want) > 1e-6 {
+			t.Errorf("got %s %g, want %g", name, got, want)
+		}
+	}
+	check("belief shift", b.BeliefShift[0], 0.2)
+	check("extremity shift", b.ExtremityShift, 0)
+	check("influence shift", b.InfluenceShift, 1)
+	check("high TV distance", b.Features[0], 0.5)
+	check("odd TV distance", b.Features[1], 0)
+
+	b = MeasureBias(population, nil, high)
+	if b.BeliefShift[0] != 0 || b.ExtremityShift != 0 || b.InfluenceShift != 0 || b.Features[0] != 0 {
+		t.Errorf("got bias %+v for an empty sample, want 0", *b)
+	}
+}
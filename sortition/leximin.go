@@ -0,0 +1,310 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortition
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/kleroterio/abm/abm"
+)
+
+// leximinEpsilon is the tolerance for improvements in column generation.
+const leximinEpsilon = 1e-7
+
+// Distribution is a probability distribution over the possible compositions
+// of assemblies that satisfy a set of quotas, as computed by [Leximin].
+// Agents that are in the same category of every quota feature are
+// interchangeable with respect to the quotas, so they are grouped into
+// types, and a panel composition specifies how many agents of each type
+// are selected. Assemblies are drawn from it with [Lottery.Draw].
+type Distribution struct {
+
+	// Pool is the pool of agents.
+	Pool []abm.Agent
+
+	// Size is the number of agents in each assembly.
+	Size int
+
+	// Types contains the indexes in [Distribution.Pool]
+	// of the agents of each type.
+	Types [][]int
+
+	// Panels contains the number of agents of each type
+	// in each possible panel composition.
+	Panels [][]int
+
+	// Weights contains the probability of each panel composition.
+	Weights []float64
+
+	// Probabilities contains the marginal probability that each agent
+	// in [Distribution.Pool] is selected.
+	Probabilities []float64
+}
+
+// Leximin returns the [Distribution] over assemblies of k agents from the
+// given pool satisfying the given quotas that is fair in the leximin sense:
+// it maximizes the minimum selection probability of any agent, then the
+// second lowest selection probability subject to that, and so on. This is
+// the algorithm of Flanigan et al. (2021), "Fair algorithms for selecting
+// citizens' assemblies", which is used by assembly organizers. It uses
+// column generation, with each panel composition found by integer
+// programming over the agent types.
+func Leximin(pool []abm.Agent, k int, quotas []Quota) (*Distribution, error) {
+	if k < 0 || k > len(pool) {
+		return nil, fmt.Errorf("sortition.Leximin: cannot select %d agents from a pool of %d", k, len(pool))
+	}
+	for i := range quotas {
+		if err := quotas[i].validate(); err != nil {
+			return nil, err
+		}
+	}
+	types, categories := agentTypes(pool, quotas)
+	nt := len(types)
+	ps := &panelSolver{k: k, quotas: quotas, categories: categories, sizes: make([]int, nt)}
+	for t, m := range types {
+		ps.sizes[t] = len(m)
+	}
+	d := &Distribution{Pool: pool, Size: k, Types: types}
+	addPanel := func(panel []int) bool {
+		if slices.ContainsFunc(d.Panels, func(p []int) bool { return slices.Equal(p, panel) }) {
+			return false
+		}
+		d.Panels = append(d.Panels, panel)
+		return true
+	}
+
+	// start with a panel that includes as many agents of each type as possible
+	for t := range nt {
+		w := make([]float64, nt)
+		w[t] = 1
+		panel, _ := ps.best(w)
+		if panel == nil {
+			return nil, errors.New("sortition.Leximin: no assembly satisfies the quotas")
+		}
+		addPanel(panel)
+	}
+
+	// fixed contains the fixed selection probability of each type,
+	// or NaN if it is not fixed yet
+	fixed := make([]float64, nt)
+	for t := range fixed {
+		fixed[t] = math.NaN()
+	}
+	for nfixed := 0; nfixed < nt; {
+		for {
+			// the dual of maximizing the minimum probability of the unfixed
+			// types, with variables y for each type and yhat for the total
+			// probability: minimize yhat - Σ_fixed fixed_t y_t subject to
+			// Σ_t panel_t/size_t y_t <= yhat for each panel
+			// and Σ_unfixed y_t = 1
+			obj := make([]float64, nt+1)
+			total := make([]float64, nt+1)
+			for t := range nt {
+				if math.IsNaN(fixed[t]) {
+					total[t] = 1
+				} else {
+					obj[t] = fixed[t]
+				}
+			}
+			obj[nt] = -1
+			prog := newLP(obj)
+			for _, panel := range d.Panels {
+				row := make([]float64, nt+1)
+				for t, c := range panel {
+					row[t] = float64(c) / float64(ps.sizes[t])
+				}
+				row[nt] = -1
+				prog.add(row, lessEqual, 0)
+			}
+			prog.add(total, equal, 1)
+			y, value, err := prog.solve()
+			if err != nil {
+				return nil, fmt.Errorf("sortition.Leximin: %w", err)
+			}
+			// find the panel that most violates the dual constraints
+			w := make([]float64, nt)
+			for t := range nt {
+				w[t] = y[t] / float64(ps.sizes[t])
+			}
+			panel, best := ps.best(w)
+			if best > y[nt]+leximinEpsilon && addPanel(panel) {
+				continue
+			}
+			// the optimal minimum probability of the unfixed types is
+			// -value, and the types with positive dual variables
+			// cannot have a higher probability
+			for t := range nt {
+				if math.IsNaN(fixed[t]) && y[t] > leximinEpsilon {
+					fixed[t] = max(-value, 0)
+					nfixed++
+				}
+			}
+			break
+		}
+	}
+
+	// find the probabilities of the panels that give the fixed probabilities
+	prog := newLP(make([]float64, len(d.Panels)))
+	for t := range nt {
+		row := make([]float64, len(d.Panels))
+		for p, panel := range d.Panels {
+			row[p] = float64(panel[t]) / float64(ps.sizes[t])
+		}
+		prog.add(row, greaterEqual, fixed[t]-leximinEpsilon)
+	}
+	ones := make([]float64, len(d.Panels))
+	for p := range ones {
+		ones[p] = 1
+	}
+	prog.add(ones, equal, 1)
+	weights, _, err := prog.solve()
+	if err != nil {
+		return nil, fmt.Errorf("sortition.Leximin: %w", err)
+	}
+	sum := 0.0
+	for p, w := range weights {
+		weights[p] = max(w, 0)
+		sum += weights[p]
+	}
+	for p := range weights {
+		weights[p] /= sum
+	}
+	d.Weights = weights
+	d.Probabilities = make([]float64, len(pool))
+	for t, members := range types {
+		prob := 0.0
+		for p, panel := range d.Panels {
+			prob += weights[p] * float64(panel[t]) / float64(ps.sizes[t])
+		}
+		for _, i := range members {
+			d.Probabilities[i] = prob
+		}
+	}
+	return d, nil
+}
+
+// Leximin selects an assembly of k agents from the given pool that satisfies
+// the given quotas from the fair distribution computed by [Leximin]. To draw
+// multiple assemblies from the same pool, use [Leximin] once and then
+// [Lottery.Draw] for each assembly, since computing the distribution
+// is much slower than drawing from it.
+func (l *Lottery) Leximin(pool []abm.Agent, k int, quotas []Quota) (*Selection, error) {
+	d, err := Leximin(pool, k, quotas)
+	if err != nil {
+		return nil, err
+	}
+	return l.Draw(d), nil
+}
+
+// Draw selects an assembly from the given distribution, by choosing a panel
+// composition according to [Distribution.Weights] and then selecting the
+// number of agents of each type in it uniformly at random from that type.
+func (l *Lottery) Draw(d *Distribution) *Selection {
+	s := &Selection{Pool: d.Pool, Probabilities: d.Probabilities}
+	u := l.Rand.Float64()
+	p := 0
+	for ; p < len(d.Weights)-1; p++ {
+		u -= d.Weights[p]
+		if u < 0 {
+			break
+		}
+	}
+	for t, c := range d.Panels[p] {
+		s.Selected = append(s.Selected, l.sample(slices.Clone(d.Types[t]), c)...)
+	}
+	slices.Sort(s.Selected)
+	return s
+}
+
+// panelSolver finds panel compositions that satisfy quotas
+// and maximize a weighted sum of the numbers of agents of each type.
+type panelSolver struct {
+
+	// k is the number of agents in each panel.
+	k int
+
+	// quotas are the quotas that panels must satisfy.
+	quotas []Quota
+
+	// categories contains the category of each type for each quota.
+	categories [][]int
+
+	// sizes contains the number of agents of each type.
+	sizes []int
+}
+
+// best returns the panel composition that maximizes the sum of the given
+// weight of each type times the number of agents of that type, and the
+// sum, using branch and bound. It returns nil if there is no such panel.
+func (ps *panelSolver) best(w []float64) ([]int, float64) {
+	var best []int
+	bestValue := math.Inf(-1)
+	lo := make([]int, len(ps.sizes))
+	var branch func(lo, hi []int)
+	branch = func(lo, hi []int) {
+		x, value, err := ps.relax(w, lo, hi)
+		if err != nil || value <= bestValue+lpEpsilon {
+			return
+		}
+		for t, xt := range x {
+			f := math.Floor(xt + 1e-6)
+			if xt-f <= 1e-6 {
+				continue
+			}
+			up, down := slices.Clone(lo), slices.Clone(hi)
+			up[t], down[t] = int(f)+1, int(f)
+			branch(up, hi)
+			branch(lo, down)
+			return
+		}
+		best = make([]int, len(x))
+		for t, xt := range x {
+			best[t] = int(math.Round(xt))
+		}
+		bestValue = value
+	}
+	branch(lo, ps.sizes)
+	return best, bestValue
+}
+
+// relax solves the linear relaxation of finding the best panel
+// composition with the given bounds on the number of each type.
+func (ps *panelSolver) relax(w []float64, lo, hi []int) ([]float64, float64, error) {
+	nt := len(ps.sizes)
+	prog := newLP(w)
+	ones := make([]float64, nt)
+	for t := range ones {
+		ones[t] = 1
+	}
+	prog.add(ones, equal, float64(ps.k))
+	for q, quota := range ps.quotas {
+		for g := range quota.Feature.Categories {
+			row := make([]float64, nt)
+			for t, cats := range ps.categories {
+				if cats[q] == g {
+					row[t] = 1
+				}
+			}
+			if quota.Min[g] > 0 {
+				prog.add(row, greaterEqual, float64(quota.Min[g]))
+			}
+			if quota.Max[g] < ps.k {
+				prog.add(row, lessEqual, float64(quota.Max[g]))
+			}
+		}
+	}
+	for t := range nt {
+		row := make([]float64, nt)
+		row[t] = 1
+		prog.add(row, lessEqual, float64(hi[t]))
+		if lo[t] > 0 {
+			prog.add(row, greaterEqual, float64(lo[t]))
+		}
+	}
+	return prog.solve()
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortition
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/kleroterio/abm/abm"
+)
+
+// testQuotas returns a pool of 60 agents and quotas for assemblies of 12 of
+// them by belief quantiles and by region, which the greedy algorithm cannot
+// satisfy with equal selection probabilities.
+func testQuotas(t *testing.T) ([]abm.Agent, []Quota) {
+	t.Helper()
+	pool := randomPool(rand.New(rand.NewPCG(1, 2)), 60, 2)
+	beliefs, err := BeliefQuantiles(pool, 0, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q0, err := ProportionalQuota(pool, beliefs, 12, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q1, err := ProportionalQuota(pool, Regions(2, 2), 12, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pool, []Quota{q0, q1}
+}
+
+// checkQuotas fails the test if the given selection
+// does not have k agents and satisfy the given quotas.
+func checkQuotas(t *testing.T, s *Selection, k int, quotas []Quota) {
+	t.Helper()
+	if len(s.Selected) != k {
+		t.Fatalf("got %d selected agents, want %d", len(s.Selected), k)
+	}
+	for _, q := range quotas {
+		counts := make([]int, len(q.Feature.Categories))
+		for _, a := range s.Agents() {
+			counts[q.Feature.Category(a)]++
+		}
+		for c, n := range counts {
+			if n < q.Min[c] || n > q.Max[c] {
+				t.Fatalf("selected %d agents in %s, want %d to %d", n, q.Feature.Categories[c], q.Min[c], q.Max[c])
+			}
+		}
+	}
+}
+
+func TestLeximin(t *testing.T) {
+	pool, quotas := testQuotas(t)
+	const k = 12
+	d, err := Leximin(pool, k, quotas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := 0.0
+	for i, p := range d.Probabilities {
+		if p < -1e-9 || p > 1+1e-9 {
+			t.Fatalf("agent %d has probability %g", i, p)
+		}
+		sum += p
+	}
+	if math.Abs(sum-k) > 1e-6 {
+		t.Errorf("probabilities sum to %g, want %d", sum, k)
+	}
+	weights := 0.0
+	for _, w := range d.Weights {
+		weights += w
+	}
+	if math.Abs(weights-1) > 1e-9 {
+		t.Errorf("weights sum to %g, want 1", weights)
+	}
+
+	l := &Lottery{Rand: rand.New(rand.NewPCG(1, 3))}
+	for range 100 {
+		checkQuotas(t, l.Draw(d), k, quotas)
+	}
+
+	g, err := l.Greedy(pool, k, quotas, 2000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lmin, gmin := slices.Min(d.Probabilities), slices.Min(g.Probabilities)
+	if lmin < gmin-1e-6 {
+		t.Errorf("leximin minimum probability %g is below the greedy minimum %g", lmin, gmin)
+	}
+}
+
+func TestLeximinUnconstrained(t *testing.T) {
+	pool := randomPool(rand.New(rand.NewPCG(1, 2)), 20, 1)
+	d, err := Leximin(pool, 5, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, p := range d.Probabilities {
+		if math.Abs(p-0.25) > 1e-6 {
+			t.Fatalf("agent %d has probability %g, want 0.25", i, p)
+		}
+	}
+	if _, err := Leximin(pool, 21, nil); err == nil {
+		t.Error("got no error for more agents than the pool")
+	}
+}
@@ -0,0 +1,240 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortition
+
+import (
+	"errors"
+	"math"
+)
+
+// lpEpsilon is the numerical tolerance of the linear program solver.
+const lpEpsilon = 1e-9
+
+var (
+	// errInfeasible is returned when a linear program has no feasible solution.
+	errInfeasible = errors.New("sortition: linear program is infeasible")
+
+	// errUnbounded is returned when a linear program has no optimal solution.
+	errUnbounded = errors.New("sortition: linear program is unbounded")
+)
+
+// senses are the types of linear program constraints.
+type sense int
+
+const (
+	lessEqual sense = iota
+	greaterEqual
+	equal
+)
+
+// lp is a linear program that maximizes c·x subject to
+// the constraints and x >= 0. It is solved with [lp.solve].
+type lp struct {
+	c      []float64
+	rows   [][]float64
+	senses []sense
+	rhs    []float64
+}
+
+// newLP returns a new linear program maximizing c·x.
+func newLP(c []float64) *lp {
+	return &lp{c: c}
+}
+
+// add adds the constraint row·x (sense) rhs.
+func (p *lp) add(row []float64, s sense, rhs float64) {
+	p.rows = append(p.rows, row)
+	p.senses = append(p.senses, s)
+	p.rhs = append(p.rhs, rhs)
+}
+
+// solve solves the linear program with the two-phase simplex method using
+// Bland's rule, returning the optimal solution and value.
+func (p *lp) solve() ([]float64, float64, error) {
+	n, m := len(p.c), len(p.rows)
+	// columns: original variables, then one slack or surplus variable
+	// per inequality, then one artificial variable per row that needs one
+	nslack := 0
+	for _, s := range p.senses {
+		if s != equal {
+			nslack++
+		}
+	}
+	nart := 0
+	artificial := make([]int, m)
+	for i := range m {
+		s := p.senses[i]
+		if p.rhs[i] < 0 {
+			s = flip(s)
+		}
+		if s == lessEqual {
+			artificial[i] = -1
+		} else {
+			artificial[i] = n + nslack + nart
+			nart++
+		}
+	}
+	ncols := n + nslack + nart
+	t := make([][]float64, m)
+	basis := make([]int, m)
+	slack := n
+	for i := range m {
+		row := make([]float64, ncols+1)
+		sign := 1.0
+		if p.rhs[i] < 0 {
+			sign = -1
+		}
+		for j, v := range p.rows[i] {
+			row[j] = sign * v
+		}
+		row[ncols] = sign * p.rhs[i]
+		s := p.senses[i]
+		switch s {
+		case lessEqual:
+			row[slack] = sign
+			slack++
+		case greaterEqual:
+			row[slack] = -sign
+			slack++
+		}
+		if artificial[i] >= 0 {
+			row[artificial[i]] = 1
+			basis[i] = artificial[i]
+		} else {
+			basis[i] = slack - 1
+		}
+		t[i] = row
+	}
+
+	// phase 1: minimize the sum of the artificial variables
+	if nart > 0 {
+		obj := make([]float64, ncols)
+		for j := n + nslack; j < ncols; j++ {
+			obj[j] = -1
+		}
+		if err := simplex(t, basis, obj, ncols); err != nil {
+			return nil, 0, err
+		}
+		if value(t, basis, obj, ncols) < -1e-7 {
+			return nil, 0, errInfeasible
+		}
+		// drive any remaining artificial variables out of the basis
+		for i := 0; i < len(t); i++ {
+			if basis[i] < n+nslack {
+				continue
+			}
+			pivoted := false
+			for j := range n + nslack {
+				if math.Abs(t[i][j]) > lpEpsilon {
+					pivot(t, basis, i, j)
+					pivoted = true
+					break
+				}
+			}
+			if !pivoted { // redundant row
+				t = append(t[:i], t[i+1:]...)
+				basis = append(basis[:i], basis[i+1:]...)
+				i--
+			}
+		}
+	}
+
+	// phase 2: maximize the objective without the artificial variables
+	obj := make([]float64, ncols)
+	copy(obj, p.c)
+	if err := simplex(t, basis, obj, n+nslack); err != nil {
+		return nil, 0, err
+	}
+	x := make([]float64, n)
+	for i, b := range basis {
+		if b < n {
+			x[b] = t[i][ncols]
+		}
+	}
+	return x, value(t, basis, obj, ncols), nil
+}
+
+// simplex runs the simplex method on the given tableau with the given basis
+// to maximize the given objective, using only the first ncols columns
+// as entering variables.
+func simplex(t [][]float64, basis []int, obj []float64, ncols int) error {
+	rhs := len(obj)
+	for {
+		// reduced costs for the current basis
+		enter := -1
+		for j := range ncols {
+			d := obj[j]
+			for i, b := range basis {
+				d -= obj[b] * t[i][j]
+			}
+			if d > lpEpsilon {
+				enter = j
+				break
+			}
+		}
+		if enter < 0 {
+			return nil
+		}
+		leave := -1
+		best := 0.0
+		for i := range t {
+			if t[i][enter] <= lpEpsilon {
+				continue
+			}
+			ratio := t[i][rhs] / t[i][enter]
+			if leave < 0 || ratio < best-lpEpsilon || (ratio <= best+lpEpsilon && basis[i] < basis[leave]) {
+				leave = i
+				best = ratio
+			}
+		}
+		if leave < 0 {
+			return errUnbounded
+		}
+		pivot(t, basis, leave, enter)
+	}
+}
+
+// pivot pivots the tableau on the given row and column.
+func pivot(t [][]float64, basis []int, row, col int) {
+	pr := t[row]
+	pv := pr[col]
+	for j := range pr {
+		pr[j] /= pv
+	}
+	for i := range t {
+		if i == row {
+			continue
+		}
+		f := t[i][col]
+		if f == 0 {
+			continue
+		}
+		ri := t[i]
+		for j := range ri {
+			ri[j] -= f * pr[j]
+		}
+	}
+	basis[row] = col
+}
+
+// value returns the value of the given objective for the current basis.
+func value(t [][]float64, basis []int, obj []float64, rhs int) float64 {
+	v := 0.0
+	for i, b := range basis {
+		v += obj[b] * t[i][rhs]
+	}
+	return v
+}
+
+// flip returns the opposite of the given inequality sense.
+func flip(s sense) sense {
+	switch s {
+	case lessEqual:
+		return greaterEqual
+	case greaterEqual:
+		return lessEqual
+	}
+	return s
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortition
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// lpTest is a linear program with a known solution.
+type lpTest struct {
+	name string
+	prog *lp
+
+	// x is the optimal solution, or nil if any optimal solution is valid.
+	x []float64
+
+	// value is the optimal value.
+	value float64
+
+	// err is the expected error.
+	err error
+}
+
+func lpTests() []lpTest {
+	var tests []lpTest
+	add := func(name string, c []float64, build func(p *lp), x []float64, value float64, err error) {
+		p := newLP(c)
+		build(p)
+		tests = append(tests, lpTest{name: name, prog: p, x: x, value: value, err: err})
+	}
+
+	add("inequalities", []float64{3, 5}, func(p *lp) {
+		p.add([]float64{1, 0}, lessEqual, 4)
+		p.add([]float64{0, 2}, lessEqual, 12)
+		p.add([]float64{3, 2}, lessEqual, 18)
+	}, []float64{2, 6}, 36, nil)
+
+	// minimize x + 2y, which needs phase 1
+	add("equality", []float64{-1, -2}, func(p *lp) {
+		p.add([]float64{1, 1}, greaterEqual, 3)
+		p.add([]float64{1, -1}, equal, 1)
+	}, []float64{2, 1}, -4, nil)
+
+	// -x - y <= -2 is x + y >= 2
+	add("negative rhs", []float64{-1, -3}, func(p *lp) {
+		p.add([]float64{-1, -1}, lessEqual, -2)
+		p.add([]float64{1, 0}, lessEqual, 5)
+	}, []float64{2, 0}, -2, nil)
+
+	add("redundant", []float64{1, 0}, func(p *lp) {
+		p.add([]float64{1, 1}, equal, 1)
+		p.add([]float64{2, 2}, equal, 2)
+	}, []float64{1, 0}, 1, nil)
+
+	// the example of Beale (1955), which cycles
+	// with the simplex method without Bland's rule
+	add("degenerate", []float64{0.75, -150, 0.02, -6}, func(p *lp) {
+		p.add([]float64{0.25, -60, -0.04, 9}, lessEqual, 0)
+		p.add([]float64{0.5, -90, -0.02, 3}, lessEqual, 0)
+		p.add([]float64{0, 0, 1, 0}, lessEqual, 1)
+	}, []float64{0.04, 0, 1, 0}, 0.05, nil)
+
+	add("infeasible", []float64{1, 1}, func(p *lp) {
+		p.add([]float64{1, 1}, lessEqual, 1)
+		p.add([]float64{1, 1}, greaterEqual, 2)
+	}, nil, 0, errInfeasible)
+
+	add("unbounded", []float64{1, 0}, func(p *lp) {
+		p.add([]float64{1, -1}, lessEqual, 1)
+	}, nil, 0, errUnbounded)
+	return tests
+}
+
+func TestLP(t *testing.T) {
+	const tolerance = 1e-9
+	for _, test := range lpTests() {
+		x, value, err := test.prog.solve()
+		if test.err != nil {
+			if !errors.Is(err, test.err) {
+				t.Errorf("%s: got error %v, want %v", test.name, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if math.Abs(value-test.value) > tolerance {
+			t.Errorf("%s: got value %g, want %g", test.name, value, test.value)
+		}
+		for j, want := range test.x {
+			if math.Abs(x[j]-want) > tolerance {
+				t.Errorf("%s: got solution %v, want %v", test.name, x, test.x)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortition
+
+import (
+	"fmt"
+
+	"github.com/kleroterio/abm/abm"
+)
+
+// Quota is a constraint on the number of selected agents
+// in each category of a feature.
+type Quota struct {
+
+	// Feature is the feature that the quota is for.
+	Feature Feature
+
+	// Min contains the minimum number of selected agents
+	// in each category of the feature.
+	Min []int
+
+	// Max contains the maximum number of selected agents
+	// in each category of the feature.
+	Max []int
+}
+
+// ProportionalQuota returns a [Quota] for an assembly of k agents from the
+// given pool for the given feature, with a range of the given slack around
+// the proportional quotas from [ProportionalQuotas] for each category.
+// It returns an error in the same cases as [ProportionalQuotas].
+func ProportionalQuota(pool []abm.Agent, feature Feature, k, slack int) (Quota, error) {
+	quotas, err := ProportionalQuotas(pool, feature, k)
+	if err != nil {
+		return Quota{}, err
+	}
+	q := Quota{Feature: feature, Min: make([]int, len(quotas)), Max: make([]int, len(quotas))}
+	for c, n := range quotas {
+		q.Min[c] = max(n-slack, 0)
+		q.Max[c] = n + slack
+	}
+	return q, nil
+}
+
+// validate returns an error if the quota is not valid.
+func (q *Quota) validate() error {
+	nc := len(q.Feature.Categories)
+	if len(q.Min) != nc || len(q.Max) != nc {
+		return fmt.Errorf("sortition: quota for %s must have %d minimums and maximums, but it has %d and %d", q.Feature.Name, nc, len(q.Min), len(q.Max))
+	}
+	for c := range nc {
+		if q.Min[c] > q.Max[c] {
+			return fmt.Errorf("sortition: quota for %s has a minimum of %d greater than its maximum of %d", q.Feature.Categories[c], q.Min[c], q.Max[c])
+		}
+	}
+	return nil
+}
+
+// agentTypes divides the agents in the given pool into types, which are groups
+// of agents that are in the same category of every quota feature, so that they
+// are interchangeable with respect to the quotas. It returns the indexes of the
+// agents in each type, and the category of each type for each quota.
+func agentTypes(pool []abm.Agent, quotas []Quota) (members [][]int, categories [][]int) {
+	byKey := map[string]int{}
+	for i, a := range pool {
+		key := make([]int, len(quotas))
+		for q := range quotas {
+			key[q] = quotas[q].Feature.Category(a)
+		}
+		ks := fmt.Sprint(key)
+		t, ok := byKey[ks]
+		if !ok {
+			t = len(categories)
+			byKey[ks] = t
+			categories = append(categories, key)
+			members = append(members, nil)
+		}
+		members[t] = append(members[t], i)
+	}
+	return members, categories
+}
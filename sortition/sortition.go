@@ -0,0 +1,157 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package sortition provides the selection of assemblies of agents
+// by lottery, such as citizens' assemblies, with support for
+// stratification and quotas.
+package sortition
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/kleroterio/abm/abm"
+)
+
+// stream is the stream of the random number generator of a [Lottery],
+// which ensures that it is independent of [abm.SimBase.Rand].
+const stream = 0x736f7274 // "sort"
+
+// Lottery selects assemblies of agents from pools of agents by lottery.
+type Lottery struct {
+
+	// Rand is the random number generator used for drawing lots.
+	Rand *rand.Rand
+}
+
+// NewLottery returns a new [Lottery] for the given simulation, with a random
+// number generator seeded from [abm.ConfigBase.Seed]. The draws are therefore
+// reproducible, but independent of the random numbers used by the simulation,
+// so drawing lots does not change the course of the simulation.
+func NewLottery(sim abm.Sim) *Lottery {
+	seed := sim.Base().Config.Base().Seed
+	return &Lottery{Rand: rand.New(rand.NewPCG(seed, stream))}
+}
+
+// Selection is an assembly selected from a pool of agents by a [Lottery].
+type Selection struct {
+
+	// Pool is the pool of agents that the assembly was selected from.
+	Pool []abm.Agent
+
+	// Selected contains the indexes of the selected agents
+	// in [Selection.Pool], in ascending order.
+	Selected []int
+
+	// Probabilities contains the probability that each agent in
+	// [Selection.Pool] is selected by the method used.
+	Probabilities []float64
+
+	// FailedTrials is the number of trials used to estimate the
+	// probabilities of [Lottery.Greedy] that failed to satisfy the quotas.
+	FailedTrials int
+}
+
+// Agents returns the selected agents.
+func (s *Selection) Agents() []abm.Agent {
+	agents := make([]abm.Agent, len(s.Selected))
+	for i, j := range s.Selected {
+		agents[i] = s.Pool[j]
+	}
+	return agents
+}
+
+// Simple selects an assembly of k agents from the given pool by simple
+// random sampling, in which every agent has the same probability of selection.
+func (l *Lottery) Simple(pool []abm.Agent, k int) (*Selection, error) {
+	if k < 0 || k > len(pool) {
+		return nil, fmt.Errorf("sortition.Lottery.Simple: cannot select %d agents from a pool of %d", k, len(pool))
+	}
+	indexes := make([]int, len(pool))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	s := &Selection{Pool: pool, Selected: l.sample(indexes, k), Probabilities: make([]float64, len(pool))}
+	for i := range s.Probabilities {
+		s.Probabilities[i] = float64(k) / float64(len(pool))
+	}
+	return s, nil
+}
+
+// Stratified selects an assembly from the given pool by stratified random
+// sampling, in which the pool is divided into strata by the categories of the
+// given feature, and the number of agents given by the quota for each category
+// is selected from it by simple random sampling. The size of the assembly is
+// the sum of the quotas. Use [ProportionalQuotas] for quotas proportional to
+// the size of each stratum, and [Cross] to stratify by multiple features.
+func (l *Lottery) Stratified(pool []abm.Agent, strata Feature, quotas []int) (*Selection, error) {
+	if len(quotas) != len(strata.Categories) {
+		return nil, fmt.Errorf("sortition.Lottery.Stratified: %d quotas for %d categories of %s", len(quotas), len(strata.Categories), strata.Name)
+	}
+	members := strata.Members(pool)
+	s := &Selection{Pool: pool, Probabilities: make([]float64, len(pool))}
+	for c, quota := range quotas {
+		if quota < 0 || quota > len(members[c]) {
+			return nil, fmt.Errorf("sortition.Lottery.Stratified: cannot select %d agents from the %d agents in %s", quota, len(members[c]), strata.Categories[c])
+		}
+		s.Selected = append(s.Selected, l.sample(members[c], quota)...)
+		for _, i := range members[c] {
+			s.Probabilities[i] = float64(quota) / float64(len(members[c]))
+		}
+	}
+	slices.Sort(s.Selected)
+	return s, nil
+}
+
+// sample returns k distinct elements of the given indexes chosen uniformly
+// at random, in ascending order. It modifies the order of the indexes.
+func (l *Lottery) sample(indexes []int, k int) []int {
+	// partial Fisher–Yates shuffle
+	for i := range k {
+		j := i + l.Rand.IntN(len(indexes)-i)
+		indexes[i], indexes[j] = indexes[j], indexes[i]
+	}
+	res := slices.Clone(indexes[:k])
+	slices.Sort(res)
+	return res
+}
+
+// ProportionalQuotas returns quotas for an assembly of k agents from the given
+// pool for the categories of the given feature that are proportional to the
+// number of agents in each category, using the largest remainder method.
+// It returns an error if k is negative or the feature has no categories.
+func ProportionalQuotas(pool []abm.Agent, feature Feature, k int) ([]int, error) {
+	if k < 0 {
+		return nil, fmt.Errorf("sortition.ProportionalQuotas: cannot select %d agents", k)
+	}
+	if len(feature.Categories) == 0 {
+		return nil, fmt.Errorf("sortition.ProportionalQuotas: %s has no categories", feature.Name)
+	}
+	members := feature.Members(pool)
+	quotas := make([]int, len(members))
+	if len(pool) == 0 {
+		return quotas, nil
+	}
+	remainders := make([]float64, len(members))
+	total := 0
+	for c, m := range members {
+		exact := float64(k) * float64(len(m)) / float64(len(pool))
+		quotas[c] = int(exact)
+		remainders[c] = exact - float64(quotas[c])
+		total += quotas[c]
+	}
+	order := make([]int, len(members))
+	for c := range order {
+		order[c] = c
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(remainders[b], remainders[a])
+	})
+	for _, c := range order[:k-total] {
+		quotas[c]++
+	}
+	return quotas, nil
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sortition
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"cogentcore.org/core/math32"
+	"github.com/kleroterio/abm/abm"
+)
+
+// randomPool returns a pool of n agents with nb uniformly random
+// beliefs and uniformly random positions.
+func randomPool(rnd *rand.Rand, n, nb int) []abm.Agent {
+	pool := make([]abm.Agent, n)
+	for i := range pool {
+		ab := &abm.AgentBase{ID: uint64(i), Beliefs: make([]float32, nb)}
+		for k := range ab.Beliefs {
+			ab.Beliefs[k] = rnd.Float32()
+		}
+		ab.Position = math32.Vec2(rnd.Float32(), rnd.Float32())
+		pool[i] = ab
+	}
+	return pool
+}
+
+func TestProportionalQuotas(t *testing.T) {
+	pool := randomPool(rand.New(rand.NewPCG(1, 2)), 100, 1)
+	f, err := BeliefQuantiles(pool, 0, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sizes := make([]int, 3)
+	for c, m := range f.Members(pool) {
+		sizes[c] = len(m)
+	}
+	if !slices.Equal(sizes, []int{33, 33, 34}) {
+		t.Errorf("got quantile sizes %v, want [33 33 34]", sizes)
+	}
+	quotas, err := ProportionalQuotas(pool, f, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(quotas, []int{3, 3, 4}) {
+		t.Errorf("got quotas %v, want [3 3 4]", quotas)
+	}
+
+	if _, err := BeliefQuantiles(pool, 0, 0); err == nil {
+		t.Error("got no error from BeliefQuantiles with 0 categories")
+	}
+	if _, err := ProportionalQuotas(pool, f, -1); err == nil {
+		t.Error("got no error from ProportionalQuotas with a negative k")
+	}
+	if _, err := ProportionalQuotas(pool, Feature{Name: "Empty"}, 10); err == nil {
+		t.Error("got no error from ProportionalQuotas with no categories")
+	}
+	if _, err := ProportionalQuota(pool, f, -1, 1); err == nil {
+		t.Error("got no error from ProportionalQuota with a negative k")
+	}
+}
@@ -0,0 +1,389 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package stop provides composable conditions for stopping simulation
+// runs, such as convergence and consensus, which are used by both
+// headless and interactive runs.
+package stop
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"cogentcore.org/core/math32"
+	"github.com/kleroterio/abm/abm"
+	"github.com/kleroterio/abm/metrics"
+)
+
+// Condition is a condition for stopping a simulation run, as used in
+// abmrun.Runner.RunUntil. Conditions can be combined with [And] and [Or].
+// Conditions can have state, so a condition should only be used
+// in one run at a time.
+type Condition interface {
+	fmt.Stringer
+
+	// Start is called at the start of a run to reset the condition.
+	Start(sim abm.Sim)
+
+	// Check returns whether the condition is met for the current state
+	// of the simulation. It is called once at the start of a run, after
+	// [Condition.Start], and then once after every step.
+	Check(sim abm.Sim) bool
+}
+
+// Result is the result of a run that was stopped by a [Condition].
+type Result struct {
+
+	// Steps is the number of steps that were run before the condition was
+	// met. For a [Stable] condition, this includes the [Stable.Steps] that
+	// confirm stability, so the time to convergence is [Result.Converged].
+	Steps int
+
+	// Converged is the number of steps from the start of the run until the
+	// beliefs became stable, as in [Stable.Converged], if a [Stable] condition
+	// is in [Result.Fired], and otherwise -1.
+	Converged int
+
+	// Condition is the condition that the run was stopped by.
+	Condition Condition
+
+	// Fired contains the individual conditions that were met when the
+	// run was stopped. For [And] and [Or], it contains the conditions
+	// inside of them that were met rather than the [And] or [Or] itself.
+	Fired []Condition
+}
+
+// NewResult returns a new [Result] for the given condition, which was just
+// met after the given number of steps.
+func NewResult(cond Condition, steps int) *Result {
+	r := &Result{Steps: steps, Condition: cond, Fired: fired(cond), Converged: -1}
+	for _, c := range r.Fired {
+		if s, ok := c.(*Stable); ok {
+			r.Converged = s.Converged()
+			break
+		}
+	}
+	return r
+}
+
+func (r *Result) String() string {
+	s := fmt.Sprintf("stopped after %d steps by %s", r.Steps, r.Reason())
+	if r.Converged >= 0 {
+		s += fmt.Sprintf(" (stable after %d steps)", r.Converged)
+	}
+	return s
+}
+
+// Reason returns the descriptions of the conditions in [Result.Fired],
+// separated by commas.
+func (r *Result) Reason() string {
+	return joinConditions(r.Fired, ", ")
+}
+
+// fired returns the individual conditions in the given condition
+// that were met in the last call to [Condition.Check].
+func fired(c Condition) []Condition {
+	var conds []Condition
+	var met []bool
+	switch c := c.(type) {
+	case *And:
+		conds, met = c.Conditions, c.met
+	case *Or:
+		conds, met = c.Conditions, c.met
+	default:
+		return []Condition{c}
+	}
+	var res []Condition
+	for i, sub := range conds {
+		if met[i] {
+			res = append(res, fired(sub)...)
+		}
+	}
+	return res
+}
+
+// And is a [Condition] that is met when all of its conditions are met.
+type And struct {
+
+	// Conditions are the conditions that must all be met.
+	Conditions []Condition
+
+	// met is whether each condition was met in the last check.
+	met []bool
+}
+
+// NewAnd returns a new [And] condition with the given conditions.
+func NewAnd(conds ...Condition) *And {
+	return &And{Conditions: conds}
+}
+
+func (c *And) String() string {
+	return "(" + joinConditions(c.Conditions, " and ") + ")"
+}
+
+func (c *And) Start(sim abm.Sim) {
+	c.met = make([]bool, len(c.Conditions))
+	for _, sub := range c.Conditions {
+		sub.Start(sim)
+	}
+}
+
+func (c *And) Check(sim abm.Sim) bool {
+	// we check every condition so that their state is always up to date
+	all := true
+	for i, sub := range c.Conditions {
+		c.met[i] = sub.Check(sim)
+		all = all && c.met[i]
+	}
+	return all
+}
+
+// Or is a [Condition] that is met when any of its conditions are met.
+type Or struct {
+
+	// Conditions are the conditions of which at least one must be met.
+	Conditions []Condition
+
+	// met is whether each condition was met in the last check.
+	met []bool
+}
+
+// NewOr returns a new [Or] condition with the given conditions.
+func NewOr(conds ...Condition) *Or {
+	return &Or{Conditions: conds}
+}
+
+func (c *Or) String() string {
+	return "(" + joinConditions(c.Conditions, " or ") + ")"
+}
+
+func (c *Or) Start(sim abm.Sim) {
+	c.met = make([]bool, len(c.Conditions))
+	for _, sub := range c.Conditions {
+		sub.Start(sim)
+	}
+}
+
+func (c *Or) Check(sim abm.Sim) bool {
+	// we check every condition so that their state is always up to date
+	met := false
+	for i, sub := range c.Conditions {
+		c.met[i] = sub.Check(sim)
+		met = met || c.met[i]
+	}
+	return met
+}
+
+// joinConditions joins the descriptions of the given conditions
+// with the given separator.
+func joinConditions(conds []Condition, sep string) string {
+	strs := make([]string, len(conds))
+	for i, c := range conds {
+		strs[i] = c.String()
+	}
+	return strings.Join(strs, sep)
+}
+
+// MaxSteps is a [Condition] that is met when [abm.SimBase.Steps]
+// reaches the given number of steps.
+type MaxSteps int
+
+func (c MaxSteps) String() string {
+	return fmt.Sprintf("max steps %d", int(c))
+}
+
+func (c MaxSteps) Start(sim abm.Sim) {}
+
+func (c MaxSteps) Check(sim abm.Sim) bool {
+	return sim.Base().Steps >= int(c)
+}
+
+// Stable is a [Condition] that is met when the mean absolute change in
+// beliefs per step (over all agents and belief axes) has been below
+// a threshold for a number of consecutive steps.
+type Stable struct {
+
+	// Threshold is the mean absolute change in beliefs per step
+	// below which a step is considered stable.
+	Threshold float32
+
+	// Steps is the number of consecutive stable steps required.
+	Steps int
+
+	// Change is the mean absolute change in beliefs in the last step.
+	Change float32
+
+	// Since is the value of [abm.SimBase.Steps] at the start of the current
+	// run of stable steps, or -1 if the last step was not stable.
+	// See [Stable.Converged] for the time to convergence.
+	Since int
+
+	// start is the value of [abm.SimBase.Steps] at the start of the run.
+	start int
+
+	// previous contains the beliefs of each agent at the last check.
+	previous [][]float32
+
+	// lastStep is the value of [abm.SimBase.Steps] at the last check.
+	lastStep int
+}
+
+// NewStable returns a new [Stable] condition with the given threshold
+// and number of steps.
+func NewStable(threshold float32, steps int) *Stable {
+	return &Stable{Threshold: threshold, Steps: steps}
+}
+
+func (c *Stable) String() string {
+	return fmt.Sprintf("belief change below %g for %d steps", c.Threshold, c.Steps)
+}
+
+func (c *Stable) Start(sim abm.Sim) {
+	c.start = sim.Base().Steps
+	c.reset(sim.Base())
+}
+
+// reset starts over the measurement of the change in beliefs.
+func (c *Stable) reset(sb *abm.SimBase) {
+	c.Change = float32(math.NaN())
+	c.Since = -1
+	c.lastStep = sb.Steps
+	c.previous = c.previous[:0]
+	for _, a := range sb.Agents {
+		c.previous = append(c.previous, append([]float32(nil), a.Base().Beliefs...))
+	}
+}
+
+func (c *Stable) Check(sim abm.Sim) bool {
+	sb := sim.Base()
+	if sb.Steps == c.lastStep {
+		return c.met(sb)
+	}
+	if len(sb.Agents) != len(c.previous) {
+		// agents were added or removed, so we start over
+		c.reset(sb)
+		return false
+	}
+	sum, n := float32(0), 0
+	for i, a := range sb.Agents {
+		for k, b := range a.Base().Beliefs {
+			sum += math32.Abs(b - c.previous[i][k])
+			c.previous[i][k] = b
+			n++
+		}
+	}
+	c.Change = sum / float32(max(n, 1))
+	if c.Change >= c.Threshold {
+		c.Since = -1
+	} else if c.Since < 0 {
+		c.Since = c.lastStep
+	}
+	c.lastStep = sb.Steps
+	return c.met(sb)
+}
+
+// Converged returns the number of steps from the start of the run to the
+// start of the current run of stable steps, which is the time to convergence
+// once the condition is met, or -1 if the last step was not stable.
+func (c *Stable) Converged() int {
+	if c.Since < 0 {
+		return -1
+	}
+	return c.Since - c.start
+}
+
+// met returns whether the condition is met for the given simulation.
+func (c *Stable) met(sb *abm.SimBase) bool {
+	return c.Since >= 0 && sb.Steps-c.Since >= c.Steps
+}
+
+// MetricTarget is a [Condition] that is met when a metric of the agents
+// in the simulation reaches a target value.
+type MetricTarget struct {
+
+	// Name is the name of the metric.
+	Name string
+
+	// Metric computes the metric for the given agents, such as
+	// one of the functions in package [metrics].
+	Metric func(agents []abm.Agent) float64
+
+	// Target is the target value of the metric.
+	Target float64
+
+	// Below is whether the target is reached when the metric is at or
+	// below the target, instead of at or above it.
+	Below bool
+
+	// Value is the value of the metric at the last check.
+	Value float64
+}
+
+func (c *MetricTarget) String() string {
+	op := ">="
+	if c.Below {
+		op = "<="
+	}
+	return fmt.Sprintf("%s %s %g", c.Name, op, c.Target)
+}
+
+func (c *MetricTarget) Start(sim abm.Sim) {
+	c.Value = math.NaN()
+}
+
+func (c *MetricTarget) Check(sim abm.Sim) bool {
+	c.Value = c.Metric(sim.Base().Agents)
+	if c.Below {
+		return c.Value <= c.Target
+	}
+	return c.Value >= c.Target
+}
+
+// Consensus returns a [MetricTarget] that is met when all of the agents
+// are in a single opinion cluster, as determined by [metrics.Clusters]
+// with the given threshold.
+func Consensus(threshold float32) *MetricTarget {
+	return &MetricTarget{Name: "Clusters", Target: 1, Below: true, Metric: func(agents []abm.Agent) float64 {
+		// if the range of beliefs on every axis is within the threshold,
+		// every pair of agents is, so we do not need to find the clusters
+		if len(agents) == 0 {
+			return 0
+		}
+		within := true
+		for i := range agents[0].Base().Beliefs {
+			within = within && metrics.Range(agents, i) <= float64(threshold)
+		}
+		if within {
+			return 1
+		}
+		return float64(len(metrics.Clusters(agents, threshold)))
+	}}
+}
+
+// Polarized returns a [MetricTarget] that is met when [metrics.Polarization]
+// reaches the given target.
+func Polarized(target float64) *MetricTarget {
+	return &MetricTarget{Name: "Polarization", Target: target, Metric: metrics.Polarization}
+}
+
+// Predicate is a [Condition] that is met when the given function returns true.
+type Predicate struct {
+
+	// Name is the description of the condition.
+	Name string
+
+	// Func returns whether the condition is met for the given simulation.
+	Func func(sim abm.Sim) bool
+}
+
+func (c *Predicate) String() string {
+	return c.Name
+}
+
+func (c *Predicate) Start(sim abm.Sim) {}
+
+func (c *Predicate) Check(sim abm.Sim) bool {
+	return c.Func(sim)
+}
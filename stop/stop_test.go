@@ -0,0 +1,63 @@
+// Copyright (c) 2025, Kleroterio. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stop
+
+import (
+	"testing"
+
+	"github.com/kleroterio/abm/abm"
+	"github.com/kleroterio/abm/metrics"
+	"github.com/kleroterio/abm/sims/basic"
+)
+
+func TestConsensus(t *testing.T) {
+	sim := abm.NewSim[basic.Sim, basic.Config]()
+	for _, threshold := range []float32{0.05, 0.2, 0.5, 1} {
+		c := Consensus(threshold)
+		c.Start(sim)
+		want := len(metrics.Clusters(sim.Agents, threshold)) == 1
+		if got := c.Check(sim); got != want {
+			t.Errorf("threshold %g: got %v, want %v", threshold, got, want)
+		}
+	}
+}
+
+func TestOrResult(t *testing.T) {
+	sim := abm.NewSim[basic.Sim, basic.Config]()
+	cond := NewOr(Polarized(100), MaxSteps(3))
+	cond.Start(sim)
+	steps := 0
+	for !cond.Check(sim) {
+		sim.Step()
+		steps++
+	}
+	res := NewResult(cond, steps)
+	if res.Steps != 3 || res.Reason() != "max steps 3" {
+		t.Fatalf("got %q after %d steps, want max steps 3 after 3 steps", res.Reason(), res.Steps)
+	}
+}
+
+func TestStableConverged(t *testing.T) {
+	sim := abm.NewSim[basic.Sim, basic.Config]()
+	for range 5 {
+		sim.Step()
+	}
+	// every step is stable with a threshold of 1,
+	// so the beliefs are stable from the start of the run
+	cond := NewOr(NewStable(1, 10), MaxSteps(100))
+	cond.Start(sim)
+	steps := 0
+	for !cond.Check(sim) {
+		sim.Step()
+		steps++
+	}
+	res := NewResult(cond, steps)
+	if res.Steps != 10 || res.Converged != 0 {
+		t.Fatalf("got %d steps and convergence after %d steps, want 10 and 0", res.Steps, res.Converged)
+	}
+	if res := NewResult(MaxSteps(3), 3); res.Converged != -1 {
+		t.Fatalf("got convergence after %d steps without a Stable condition, want -1", res.Converged)
+	}
+}